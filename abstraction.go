@@ -0,0 +1,385 @@
+package poker
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"math"
+	"math/rand"
+	"os"
+)
+
+var (
+	errWrongAbstractionConfig = errors.New("the abstraction needs at least one bucket, bin, runout and opponent")
+	errTooManyBuckets         = errors.New("the abstraction can't have more than 65535 buckets")
+	errWrongAbstractionFile   = errors.New("the file is not a card abstraction")
+)
+
+// abstractionMagic starts every file written by CardAbstraction.Save.
+const abstractionMagic = "PKAB"
+
+// AbstractionConfig are the parameters to build a CardAbstraction.
+//
+// Each (hand, board) pair has a histogram of Bins bins with the equity it has at the RIVER,
+// over Runouts random runouts, and the equity of each runout is estimated against Opponents random hands.
+// The histograms of Samples random pairs (every pair if it is 0) are clustered into Buckets buckets
+// with k-means (at most Iterations iterations), using the earth mover's distance between them,
+// and then every pair goes to the bucket with the nearest center.
+// If Rand is nil, the global random generator is used.
+type AbstractionConfig struct {
+	Buckets    int
+	Bins       int
+	Runouts    int
+	Opponents  int
+	Iterations int
+	Samples    int
+	Rand       *rand.Rand
+}
+
+// CardAbstraction groups the (hand, board) pairs of one BoardState in buckets,
+// where the hands of a bucket have similar equity distributions, so they can be played the same way.
+// Build it with NewCardAbstraction, or load it with LoadCardAbstraction.
+type CardAbstraction struct {
+	State   BoardState
+	Buckets int
+	buckets []uint16
+	indexer *HandIndexer
+}
+
+// NewCardAbstraction computes the equity histograms of the canonical (hand, board) pairs of the state, and clusters them.
+// Beware it takes a long time after the PREFLOP, even with few Samples, because every pair needs its histogram
+// to find its bucket (there are more than a million pairs in the FLOP).
+func NewCardAbstraction(state BoardState, config AbstractionConfig) (*CardAbstraction, error) {
+	if config.Buckets <= 0 || config.Bins <= 0 || config.Runouts <= 0 || config.Opponents <= 0 || config.Samples < 0 {
+		return nil, errWrongAbstractionConfig
+	}
+	if config.Buckets > math.MaxUint16 {
+		return nil, errTooManyBuckets
+	}
+
+	indexer := NewHandIndexer(state)
+	ca := &CardAbstraction{
+		State:   state,
+		Buckets: config.Buckets,
+		buckets: make([]uint16, indexer.Size()),
+		indexer: indexer,
+	}
+
+	samples := indexer.Size()
+	if config.Samples > 0 && uint64(config.Samples) < samples {
+		samples = uint64(config.Samples)
+	}
+	int63n := rand.Int63n
+	if config.Rand != nil {
+		int63n = config.Rand.Int63n
+	}
+
+	// The histograms of the samples go one after another in the same slice
+	bins := config.Bins
+	indexes := make([]uint64, samples)
+	histograms := make([]float64, samples*uint64(bins))
+	for i := range indexes {
+		indexes[i] = uint64(i)
+		if samples < indexer.Size() {
+			indexes[i] = uint64(int63n(int64(indexer.Size())))
+		}
+
+		if err := ca.histogram(histograms[i*bins:(i+1)*bins], indexes[i], config); err != nil {
+			return nil, err
+		}
+	}
+
+	centers, assignments := kMeans(histograms, config)
+	if samples == indexer.Size() {
+		for i, bucket := range assignments {
+			ca.buckets[i] = uint16(bucket)
+		}
+		return ca, nil
+	}
+
+	histogram := make([]float64, bins)
+	for index := range ca.buckets {
+		if err := ca.histogram(histogram, uint64(index), config); err != nil {
+			return nil, err
+		}
+
+		bucket, _ := nearestCenter(histogram, centers)
+		ca.buckets[index] = uint16(bucket)
+	}
+
+	return ca, nil
+}
+
+// histogram fills the histogram with the EquityHistogram of the (hand, board) pair of the index.
+func (ca *CardAbstraction) histogram(histogram []float64, index uint64, config AbstractionConfig) error {
+	hand, board, err := ca.indexer.Unindex(index)
+	if err != nil {
+		return err
+	}
+
+	equityHistogram(histogram, hand, board, config)
+	return nil
+}
+
+// Bucket returns the bucket of the (hand, board) pair, from 0 to Buckets-1.
+// Returns an error if the pair is not of the state of the abstraction.
+func (ca *CardAbstraction) Bucket(hand, board Cards) (int, error) {
+	index, err := ca.indexer.Index(hand, board)
+	if err != nil {
+		return 0, err
+	}
+
+	return int(ca.buckets[index]), nil
+}
+
+// Save writes the abstraction to the file in path, to be loaded with LoadCardAbstraction.
+func (ca *CardAbstraction) Save(path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	w := bufio.NewWriter(f)
+	header := []any{[]byte(abstractionMagic), uint32(ca.State), uint32(ca.Buckets), uint64(len(ca.buckets)), ca.buckets}
+	for _, data := range header {
+		if err := binary.Write(w, binary.LittleEndian, data); err != nil {
+			return err
+		}
+	}
+
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	return f.Close()
+}
+
+// LoadCardAbstraction reads the abstraction saved in the file in path.
+// Returns an error if the file is not a card abstraction, or if it doesn't match its state or its number of buckets.
+func LoadCardAbstraction(path string) (*CardAbstraction, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	r := bufio.NewReader(f)
+	magic := make([]byte, len(abstractionMagic))
+	var state, buckets uint32
+	var size uint64
+	for _, data := range []any{magic, &state, &buckets, &size} {
+		if err := binary.Read(r, binary.LittleEndian, data); err != nil {
+			return nil, errWrongAbstractionFile
+		}
+	}
+
+	if string(magic) != abstractionMagic || BoardState(state) > SHOWDOWN || buckets == 0 || buckets > math.MaxUint16 {
+		return nil, errWrongAbstractionFile
+	}
+
+	ca := &CardAbstraction{
+		State:   BoardState(state),
+		Buckets: int(buckets),
+		indexer: NewHandIndexer(BoardState(state)),
+	}
+	if size != ca.indexer.Size() {
+		return nil, errWrongAbstractionFile
+	}
+
+	ca.buckets = make([]uint16, size)
+	if err := binary.Read(r, binary.LittleEndian, ca.buckets); err != nil {
+		return nil, errWrongAbstractionFile
+	}
+	for _, bucket := range ca.buckets {
+		if int(bucket) >= ca.Buckets {
+			return nil, errWrongAbstractionFile
+		}
+	}
+
+	return ca, nil
+}
+
+// EquityHistogram returns the histogram of the equity of the hand at the RIVER (see AbstractionConfig),
+// normalized so the bins sum 1. Bin i has the equities from i/Bins to (i+1)/Bins.
+func EquityHistogram(hand Cards, tableCards []Cards, config AbstractionConfig) []float64 {
+	histogram := make([]float64, config.Bins)
+	equityHistogram(histogram, hand, JoinCards(tableCards...), config)
+
+	return histogram
+}
+
+// equityHistogram fills the histogram (with zeros, and len(histogram) bins) like EquityHistogram.
+func equityHistogram(histogram []float64, hand, board Cards, config AbstractionConfig) {
+	intn := rand.Intn
+	if config.Rand != nil {
+		intn = config.Rand.Intn
+	}
+
+	for bin := range histogram {
+		histogram[bin] = 0
+	}
+	runouts := config.Runouts
+	if board.Count() >= MAX_CARDS_IN_BOARD {
+		runouts = 1
+	}
+
+	live := ALL_CARDS.QuitCards(hand | board).Split()
+	deck := make([]Cards, 0, len(live))
+	for n := 0; n < runouts; n++ {
+		// The runout is taken out of the deck, and the opponents' cards from what is left
+		deck = append(deck[:0], live...)
+		final := board
+		for k := board.Count(); k < MAX_CARDS_IN_BOARD; k++ {
+			j := intn(len(deck))
+			final |= deck[j]
+			deck[j] = deck[len(deck)-1]
+			deck = deck[:len(deck)-1]
+		}
+
+		value := handValue(hand | final)
+		var equity float64
+		for o := 0; o < config.Opponents; o++ {
+			i, j := intn(len(deck)), intn(len(deck)-1)
+			if j >= i {
+				j++
+			}
+
+			switch opponent := handValue(deck[i] | deck[j] | final); {
+			case value > opponent:
+				equity++
+			case value == opponent:
+				equity += 0.5
+			}
+		}
+		equity /= float64(config.Opponents)
+
+		bin := int(equity * float64(len(histogram)))
+		if bin >= len(histogram) {
+			bin = len(histogram) - 1
+		}
+		histogram[bin] += 1 / float64(runouts)
+	}
+}
+
+// emd returns the earth mover's distance between two histograms with the same bins and the same total,
+// which in one dimension is the sum of the differences between their cumulative distributions.
+func emd(a, b []float64) float64 {
+	var distance, cumA, cumB float64
+	for i := range a {
+		cumA += a[i]
+		cumB += b[i]
+		distance += math.Abs(cumA - cumB)
+	}
+
+	return distance
+}
+
+// kMeans clusters the histograms (one after another, with config.Bins bins each) in config.Buckets clusters
+// (initialized with k-means++), and returns the centers (in the same way) and the cluster of each histogram.
+func kMeans(histograms []float64, config AbstractionConfig) (centers []float64, assignments []int) {
+	random, intn := rand.Float64, rand.Intn
+	if config.Rand != nil {
+		random, intn = config.Rand.Float64, config.Rand.Intn
+	}
+
+	bins := config.Bins
+	n := len(histograms) / bins
+	assignments = make([]int, n)
+	if n == 0 {
+		return nil, assignments
+	}
+	histogram := func(i int) []float64 {
+		return histograms[i*bins : (i+1)*bins]
+	}
+
+	k := config.Buckets
+	if k > n {
+		k = n
+	}
+
+	// k-means++: every new center is chosen with probability proportional to its squared distance to the nearest center
+	centers = make([]float64, 0, k*bins)
+	centers = append(centers, histogram(intn(n))...)
+	distances := make([]float64, n)
+	for i := range distances {
+		distances[i] = math.Inf(1)
+	}
+	for len(centers) < k*bins {
+		last := centers[len(centers)-bins:]
+		var total float64
+		for i := range distances {
+			d := emd(histogram(i), last)
+			distances[i] = math.Min(distances[i], d*d)
+			total += distances[i]
+		}
+
+		next := intn(n)
+		if total > 0 {
+			x := random() * total
+			for i, d := range distances {
+				if x < d {
+					next = i
+					break
+				}
+				x -= d
+			}
+		}
+		centers = append(centers, histogram(next)...)
+	}
+
+	for i := range assignments {
+		assignments[i], _ = nearestCenter(histogram(i), centers)
+	}
+
+	sums := make([]float64, len(centers))
+	counts := make([]int, k)
+	for it := 0; it < config.Iterations; it++ {
+		for c := range sums {
+			sums[c] = 0
+		}
+		for c := range counts {
+			counts[c] = 0
+		}
+		for i, c := range assignments {
+			counts[c]++
+			for bin, v := range histogram(i) {
+				sums[c*bins+bin] += v
+			}
+		}
+		for c, count := range counts {
+			if count == 0 {
+				continue
+			}
+			for bin := c * bins; bin < (c+1)*bins; bin++ {
+				centers[bin] = sums[bin] / float64(count)
+			}
+		}
+
+		changed := false
+		for i := range assignments {
+			if c, _ := nearestCenter(histogram(i), centers); c != assignments[i] {
+				assignments[i] = c
+				changed = true
+			}
+		}
+		if !changed {
+			break
+		}
+	}
+
+	return centers, assignments
+}
+
+// nearestCenter returns the center (one after another, with len(h) bins each) with the smallest emd to h,
+// and the distance.
+func nearestCenter(h, centers []float64) (int, float64) {
+	bins := len(h)
+	best, bestDistance := 0, math.Inf(1)
+	for c := 0; c < len(centers)/bins; c++ {
+		if d := emd(h, centers[c*bins:(c+1)*bins]); d < bestDistance {
+			best, bestDistance = c, d
+		}
+	}
+
+	return best, bestDistance
+}
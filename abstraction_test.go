@@ -0,0 +1,165 @@
+package poker_test
+
+import (
+	"encoding/binary"
+	"math"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/arturo-source/poker-engine"
+)
+
+func TestEquityHistogramOfTheNuts(t *testing.T) {
+	c := poker.NewCard
+	config := poker.AbstractionConfig{Bins: 10, Runouts: 5, Opponents: 20, Rand: rand.New(rand.NewSource(1))}
+	histogram := poker.EquityHistogram(c("Jh")|c("Th"), []poker.Cards{c("Ah"), c("Kh"), c("Qh"), c("7c"), c("2d")}, config)
+
+	if len(histogram) != 10 || histogram[9] != 1 {
+		t.Errorf("All the equity should be in the last bin: %v", histogram)
+	}
+}
+
+func TestEquityHistogramSumsOne(t *testing.T) {
+	c := poker.NewCard
+	config := poker.AbstractionConfig{Bins: 8, Runouts: 30, Opponents: 10, Rand: rand.New(rand.NewSource(1))}
+	histogram := poker.EquityHistogram(c("9s")|c("8s"), []poker.Cards{c("Ts"), c("7d"), c("2s")}, config)
+
+	var sum float64
+	for _, v := range histogram {
+		sum += v
+	}
+	if math.Abs(sum-1) > 1e-9 {
+		t.Errorf("\nWant %f\nGot  %f", 1.0, sum)
+	}
+}
+
+func TestPreflopAbstraction(t *testing.T) {
+	c := poker.NewCard
+	config := poker.AbstractionConfig{Buckets: 5, Bins: 10, Runouts: 30, Opponents: 10, Iterations: 20, Rand: rand.New(rand.NewSource(1))}
+	ca, err := poker.NewCardAbstraction(poker.PREFLOP, config)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	aces, _ := ca.Bucket(c("Ah")|c("As"), poker.NO_CARD)
+	sevenTwo, _ := ca.Bucket(c("7h")|c("2c"), poker.NO_CARD)
+	if aces == sevenTwo {
+		t.Errorf("AA and 72o shouldn't be in the same bucket")
+	}
+
+	// Suit isomorphic hands are in the same bucket
+	other, _ := ca.Bucket(c("Ad")|c("Ac"), poker.NO_CARD)
+	if aces != other {
+		t.Errorf("\nWant %d\nGot  %d", aces, other)
+	}
+
+	path := filepath.Join(t.TempDir(), "preflop.abs")
+	if err := ca.Save(path); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	loaded, err := poker.LoadCardAbstraction(path)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if loaded.State != poker.PREFLOP || loaded.Buckets != 5 {
+		t.Errorf("Wrong abstraction loaded: %v %d", loaded.State, loaded.Buckets)
+	}
+
+	for i := 0; i < 100; i++ {
+		hand := randomCards(rand.New(rand.NewSource(int64(i))), 2, poker.NO_CARD)
+		want, _ := ca.Bucket(hand, poker.NO_CARD)
+		got, _ := loaded.Bucket(hand, poker.NO_CARD)
+		if want != got {
+			t.Fatalf("%s\nWant %d\nGot  %d", hand, want, got)
+		}
+	}
+}
+
+func TestSampledAbstraction(t *testing.T) {
+	c := poker.NewCard
+	config := poker.AbstractionConfig{Buckets: 5, Bins: 10, Runouts: 30, Opponents: 10, Iterations: 20, Samples: 60, Rand: rand.New(rand.NewSource(1))}
+	ca, err := poker.NewCardAbstraction(poker.PREFLOP, config)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	// Every pair has a bucket, although only some of them are clustered
+	for hc := poker.HandClass(0); hc < poker.HAND_CLASSES; hc++ {
+		bucket, err := ca.Bucket(hc.Hands()[0], poker.NO_CARD)
+		if err != nil || bucket < 0 || bucket >= ca.Buckets {
+			t.Fatalf("%s: wrong bucket %d (%v)", hc, bucket, err)
+		}
+	}
+
+	aces, _ := ca.Bucket(c("Ah")|c("As"), poker.NO_CARD)
+	sevenTwo, _ := ca.Bucket(c("7h")|c("2c"), poker.NO_CARD)
+	if aces == sevenTwo {
+		t.Errorf("AA and 72o shouldn't be in the same bucket")
+	}
+}
+
+func TestAbstractionWrongConfig(t *testing.T) {
+	if _, err := poker.NewCardAbstraction(poker.PREFLOP, poker.AbstractionConfig{}); err == nil {
+		t.Errorf("An empty config should return an error")
+	}
+	if _, err := poker.LoadCardAbstraction(filepath.Join(t.TempDir(), "missing")); err == nil {
+		t.Errorf("A missing file should return an error")
+	}
+}
+
+func TestLoadWrongAbstraction(t *testing.T) {
+	config := poker.AbstractionConfig{Buckets: 5, Bins: 10, Runouts: 10, Opponents: 10, Rand: rand.New(rand.NewSource(1))}
+	ca, err := poker.NewCardAbstraction(poker.PREFLOP, config)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	path := filepath.Join(t.TempDir(), "preflop.abs")
+	if err := ca.Save(path); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	// The header is the magic, the state, the buckets and the size, then the bucket of every pair
+	const bucketsAt, sizeAt, headerSize = 8, 12, 20
+	corrupt := func(change func(data []byte) []byte) string {
+		bad := change(append([]byte(nil), data...))
+		badPath := filepath.Join(t.TempDir(), "bad.abs")
+		if err := os.WriteFile(badPath, bad, 0o644); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		return badPath
+	}
+
+	tests := map[string]string{
+		"wrong magic": corrupt(func(data []byte) []byte {
+			data[0] = 'X'
+			return data
+		}),
+		"less buckets than the ones used": corrupt(func(data []byte) []byte {
+			binary.LittleEndian.PutUint32(data[bucketsAt:], 1)
+			return data
+		}),
+		"a bucket out of range": corrupt(func(data []byte) []byte {
+			binary.LittleEndian.PutUint16(data[headerSize:], 5)
+			return data
+		}),
+		"wrong size": corrupt(func(data []byte) []byte {
+			binary.LittleEndian.PutUint64(data[sizeAt:], 170)
+			return data
+		}),
+		"truncated": corrupt(func(data []byte) []byte {
+			return data[:len(data)-1]
+		}),
+	}
+
+	for name, path := range tests {
+		if _, err := poker.LoadCardAbstraction(path); err == nil {
+			t.Errorf("%s: wanted an error. Got nil.", name)
+		}
+	}
+}
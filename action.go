@@ -0,0 +1,46 @@
+package poker
+
+// ActionKind represents the kind of move a player does in a betting round (FOLD, CHECK, etc.).
+// SHOW and MUCK are not betting moves: they are what the players do with their cards when the hand is over.
+// RUNOUT is not done by a player (its Seat is NO_TURN): it is each board dealt when the board is run more than once,
+// and its Amount is the number of the runout (see Game.Runouts).
+type ActionKind int
+
+const (
+	FOLD ActionKind = iota
+	CHECK
+	CALL
+	BET
+	RAISE
+	SHOW
+	MUCK
+	RUNOUT
+)
+
+func (ak ActionKind) String() string {
+	names := [...]string{
+		"Fold",
+		"Check",
+		"Call",
+		"Bet",
+		"Raise",
+		"Show",
+		"Muck",
+		"Runout",
+	}
+
+	if ak < FOLD || ak > RUNOUT {
+		return "Unknown ActionKind"
+	}
+
+	return names[ak]
+}
+
+// Action represents a move done by the player sitting in Seat (the index in Game.Players) during a BoardState.
+// Amount is the total coins the player has bet in the current round after the action.
+type Action struct {
+	Seat   int
+	Kind   ActionKind
+	Amount uint
+	State  BoardState
+}
@@ -0,0 +1,138 @@
+package poker
+
+import (
+	"math/rand"
+	"sort"
+)
+
+// ActionAbstraction is a small set of bets, so bots only have to decide between a few actions.
+// BetSizes are fractions of the pot after calling (0.5 is half pot, 1 is pot),
+// and if AllIn is true, betting every coin is one of the actions too.
+type ActionAbstraction struct {
+	BetSizes []float64
+	AllIn    bool
+}
+
+// Actions returns the abstract actions of the player in Turn, which are all legal:
+// FOLD, CHECK or CALL as in Game.LegalActions, and a BET or RAISE for each bet size (and all-in), sorted by Amount.
+// Bet sizes smaller than the minimum raise are raised to the minimum, bigger ones are lowered to all-in,
+// and bet sizes with the same Amount are returned only once.
+func (aa ActionAbstraction) Actions(g *Game) []Action {
+	legalActions := g.LegalActions()
+	actions := make([]Action, 0, len(legalActions)+len(aa.BetSizes))
+
+	var bet Action
+	var min, max uint
+	canBet := false
+	for _, a := range legalActions {
+		switch a.Kind {
+		case BET, RAISE:
+			if !canBet {
+				bet, min, canBet = a, a.Amount, true
+			}
+			max = a.Amount
+		default:
+			actions = append(actions, a)
+		}
+	}
+	if !canBet {
+		return actions
+	}
+
+	amounts := make([]uint, 0, len(aa.BetSizes)+1)
+	for _, size := range aa.BetSizes {
+		amounts = append(amounts, clampUint(potBet(g, size), min, max))
+	}
+	if aa.AllIn {
+		amounts = append(amounts, max)
+	}
+	sort.Slice(amounts, func(i, j int) bool { return amounts[i] < amounts[j] })
+
+	for i, amount := range amounts {
+		if i > 0 && amount == amounts[i-1] {
+			continue
+		}
+		bet.Amount = amount
+		actions = append(actions, bet)
+	}
+
+	return actions
+}
+
+// Translate maps any legal action of the player in Turn to one of the abstract Actions.
+// FOLD, CHECK and CALL are kept, and a BET or RAISE between two abstract bets is mapped
+// to one of them at random, with the pseudo-harmonic mapping (see PseudoHarmonic) of their pot fractions.
+// Bets smaller or bigger than every abstract bet are mapped to the smallest or the biggest one.
+// If r is nil, the global random generator is used.
+func (aa ActionAbstraction) Translate(g *Game, a Action, r *rand.Rand) Action {
+	random := rand.Float64
+	if r != nil {
+		random = r.Float64
+	}
+
+	actions := aa.Actions(g)
+	bets := make([]Action, 0, len(actions))
+	for _, abstract := range actions {
+		switch {
+		case abstract.Kind == BET || abstract.Kind == RAISE:
+			bets = append(bets, abstract)
+		case abstract.Kind == a.Kind:
+			return abstract
+		}
+	}
+	if len(bets) == 0 || (a.Kind != BET && a.Kind != RAISE) {
+		return a
+	}
+
+	if a.Amount <= bets[0].Amount {
+		return bets[0]
+	}
+	for i := 1; i < len(bets); i++ {
+		if a.Amount > bets[i].Amount {
+			continue
+		}
+
+		lower, upper := bets[i-1], bets[i]
+		if a.Amount == upper.Amount {
+			return upper
+		}
+
+		x := potFraction(g, a.Amount)
+		if random() < PseudoHarmonic(potFraction(g, lower.Amount), potFraction(g, upper.Amount), x) {
+			return lower
+		}
+		return upper
+	}
+
+	return bets[len(bets)-1]
+}
+
+// PseudoHarmonic returns the probability of mapping a bet of x (as a fraction of the pot)
+// to the smaller abstract bet a, instead of the bigger one b, with a <= x <= b.
+// It is the pseudo-harmonic mapping of Ganzfried and Sandholm: ((b - x) * (1 + a)) / ((b - a) * (1 + x)).
+func PseudoHarmonic(a, b, x float64) float64 {
+	if b <= a {
+		return 1
+	}
+
+	return ((b - x) * (1 + a)) / ((b - a) * (1 + x))
+}
+
+// potBet returns the Amount of a bet or a raise of a fraction of the pot (counting the call) for the player in Turn.
+func potBet(g *Game, fraction float64) uint {
+	currentBet := g.CurrentBet()
+	potAfterCall := g.TotalPot() + currentBet - g.Players[g.Turn].BetCoins
+
+	return currentBet + uint(fraction*float64(potAfterCall))
+}
+
+// potFraction returns which fraction of the pot (counting the call) is a bet or a raise to amount for the player in Turn.
+func potFraction(g *Game, amount uint) float64 {
+	currentBet := g.CurrentBet()
+	potAfterCall := g.TotalPot() + currentBet - g.Players[g.Turn].BetCoins
+	if potAfterCall == 0 {
+		potAfterCall = 1
+	}
+
+	return float64(int(amount)-int(currentBet)) / float64(potAfterCall)
+}
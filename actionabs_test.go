@@ -0,0 +1,84 @@
+package poker_test
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/arturo-source/poker-engine"
+)
+
+func TestAbstractActions(t *testing.T) {
+	g := newBettingGame(100, 100)
+	if err := g.StartHand(); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	// Pot after calling is 4: half pot raises to 4, pot to 6
+	aa := poker.ActionAbstraction{BetSizes: []float64{0.5, 1, 0.25}, AllIn: true}
+	actions := aa.Actions(g)
+
+	want := []poker.Action{
+		{Seat: g.Turn, Kind: poker.FOLD, Amount: 1},
+		{Seat: g.Turn, Kind: poker.CALL, Amount: 2},
+		{Seat: g.Turn, Kind: poker.RAISE, Amount: 4},
+		{Seat: g.Turn, Kind: poker.RAISE, Amount: 6},
+		{Seat: g.Turn, Kind: poker.RAISE, Amount: 100},
+	}
+	if len(want) != len(actions) {
+		t.Fatalf("\nWant %v\nGot  %v", want, actions)
+	}
+	for i := range want {
+		if want[i] != actions[i] {
+			t.Errorf("\nWant %v\nGot  %v", want[i], actions[i])
+		}
+	}
+
+	for _, a := range actions {
+		if err := g.Clone().Apply(a); err != nil {
+			t.Errorf("Abstract action %v should be legal: %s", a, err)
+		}
+	}
+}
+
+func TestPseudoHarmonicTranslation(t *testing.T) {
+	g := newBettingGame(100, 100)
+	if err := g.StartHand(); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	aa := poker.ActionAbstraction{BetSizes: []float64{0.5, 1}, AllIn: true}
+	r := rand.New(rand.NewSource(1))
+
+	// Raising to 5 is 0.75 pot, between half pot (4) and pot (6)
+	want := poker.PseudoHarmonic(0.5, 1, 0.75)
+	if math.Abs(want-0.375/0.875) > 1e-9 {
+		t.Errorf("\nWant %f\nGot  %f", 0.375/0.875, want)
+	}
+
+	lower := 0
+	samples := 10000
+	for i := 0; i < samples; i++ {
+		a := aa.Translate(g, poker.Action{Seat: g.Turn, Kind: poker.RAISE, Amount: 5}, r)
+		switch a.Amount {
+		case 4:
+			lower++
+		case 6:
+		default:
+			t.Fatalf("Raise mapped to %d", a.Amount)
+		}
+	}
+	if got := float64(lower) / float64(samples); math.Abs(want-got) > 0.02 {
+		t.Errorf("\nWant %f\nGot  %f", want, got)
+	}
+
+	call := aa.Translate(g, poker.Action{Seat: g.Turn, Kind: poker.CALL, Amount: 2}, r)
+	if call.Kind != poker.CALL {
+		t.Errorf("\nWant %s\nGot  %s", poker.CALL, call.Kind)
+	}
+
+	exact := aa.Translate(g, poker.Action{Seat: g.Turn, Kind: poker.RAISE, Amount: 6}, r)
+	if exact.Amount != 6 {
+		t.Errorf("\nWant %d\nGot  %d", 6, exact.Amount)
+	}
+}
@@ -0,0 +1,91 @@
+package poker
+
+import (
+	"fmt"
+	"math/rand"
+)
+
+// Agent is a bot that decides what to do when it is its turn.
+// It receives the game as its player sees it, and the legal actions it can do,
+// and returns the action it wants to do (see Game.LegalActions to know which Amounts are legal).
+type Agent interface {
+	Act(view GameView, legalActions []Action) Action
+}
+
+// Match seats the Agents at a Game and plays Hands hands between them, without any human interaction.
+// Every hand starts with StartingCoins for each agent, and the button moves one seat after each hand.
+//
+// In Duplicate mode, every deal is played once per agent, rotating the agents through the seats
+// (the cards stay in the same seats), so the luck of the cards is shared between agents.
+//
+// Rand is the source used to shuffle the deck, if it is nil the global one from math/rand is used.
+type Match struct {
+	Agents        []Agent
+	Hands         int
+	StartingCoins uint
+	SmallBlind    uint
+	BigBlind      uint
+	Duplicate     bool
+	Rand          *rand.Rand
+}
+
+// Run plays the match, and returns the coins won (or lost, if negative) by each agent.
+// Returns an error if there are less than two agents, or if an agent does an illegal action.
+func (m *Match) Run() ([]int, error) {
+	n := len(m.Agents)
+	if n < 2 {
+		return nil, errNotEnoughPlayers
+	}
+
+	g := NewGame()
+	g.SmallBlind = m.SmallBlind
+	g.BigBlind = m.BigBlind
+	g.Deck.Rand = m.Rand
+	g.Players = make([]*Player, n)
+
+	players := make([]*Player, n)
+	for i := range players {
+		players[i] = NewPlayer(fmt.Sprintf("Agent %d", i))
+	}
+
+	rotations := 1
+	if m.Duplicate {
+		rotations = n
+	}
+
+	results := make([]int, n)
+	for hand := 0; hand < m.Hands; hand++ {
+		g.Deck.Shuffle()
+		deal := append([]Cards(nil), g.Deck.cards...)
+
+		for r := 0; r < rotations; r++ {
+			// the seat i is played by the agent (i + r) % n
+			for seat := range g.Players {
+				g.Players[seat] = players[(seat+r)%n]
+				g.Players[seat].Coins = m.StartingCoins
+			}
+
+			g.Board.Restart()
+			copy(g.Deck.cards, deal)
+			if err := g.startHand(); err != nil {
+				return nil, err
+			}
+
+			for !g.HandIsOver() {
+				agent := (g.Turn + r) % n
+				action := m.Agents[agent].Act(g.ViewFor(g.Players[g.Turn]), g.LegalActions())
+				if err := g.Apply(action); err != nil {
+					return nil, fmt.Errorf("agent %d: %w", agent, err)
+				}
+			}
+
+			for seat, p := range g.Players {
+				results[(seat+r)%n] += int(p.Coins) - int(m.StartingCoins)
+			}
+		}
+
+		g.Button = (g.Button + 1) % n
+	}
+
+	return results, nil
+}
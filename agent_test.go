@@ -0,0 +1,87 @@
+package poker_test
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/arturo-source/poker-engine"
+)
+
+// callingAgent always checks or calls.
+type callingAgent struct{}
+
+func (callingAgent) Act(view poker.GameView, legalActions []poker.Action) poker.Action {
+	for _, a := range legalActions {
+		if a.Kind == poker.CHECK || a.Kind == poker.CALL {
+			return a
+		}
+	}
+
+	return legalActions[0]
+}
+
+// randomAgent does any legal action.
+type randomAgent struct {
+	r *rand.Rand
+}
+
+func (a randomAgent) Act(view poker.GameView, legalActions []poker.Action) poker.Action {
+	return legalActions[a.r.Intn(len(legalActions))]
+}
+
+func TestMatchIsZeroSum(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	m := poker.Match{
+		Agents:        []poker.Agent{randomAgent{r}, randomAgent{r}, callingAgent{}},
+		Hands:         200,
+		StartingCoins: 100,
+		SmallBlind:    1,
+		BigBlind:      2,
+		Rand:          r,
+	}
+
+	results, err := m.Run()
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	sum := 0
+	for _, res := range results {
+		sum += res
+	}
+	if sum != 0 {
+		t.Errorf("Results are not zero sum: %v", results)
+	}
+}
+
+func TestDuplicateMatchBetweenEqualAgents(t *testing.T) {
+	m := poker.Match{
+		Agents:        []poker.Agent{callingAgent{}, callingAgent{}},
+		Hands:         50,
+		StartingCoins: 100,
+		SmallBlind:    1,
+		BigBlind:      2,
+		Duplicate:     true,
+		Rand:          rand.New(rand.NewSource(1)),
+	}
+
+	results, err := m.Run()
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	for i, res := range results {
+		if res != 0 {
+			t.Errorf("Agent %d won %d coins playing against itself", i, res)
+		}
+	}
+}
+
+func TestMatchNeedsTwoAgents(t *testing.T) {
+	m := poker.Match{Agents: []poker.Agent{callingAgent{}}, Hands: 1}
+
+	_, err := m.Run()
+	if err == nil {
+		t.Errorf("Wanted an error. Got nil.")
+	}
+}
@@ -0,0 +1,544 @@
+package poker
+
+import "sort"
+
+// NO_TURN is the Game.Turn when nobody has to act.
+const NO_TURN = -1
+
+// SidePot is an amount of coins that only the Players who contributed to it can win.
+// The first pot returned by Game.Pots is the main pot.
+type SidePot struct {
+	Coins   uint
+	Players []*Player
+}
+
+// StartHand shuffles the deck, sets the board to PREFLOP, posts the antes and the blinds, and deals the cards.
+// Players without coins sit out the hand (they are marked as folded).
+// Returns an error if there are not at least two players with coins.
+func (g *Game) StartHand() error {
+	g.Board.Restart()
+	return g.startHand()
+}
+
+// startHand does everything StartHand does except shuffling the deck,
+// so the cards are dealt in the current deck order.
+func (g *Game) startHand() error {
+	g.Pot = 0
+	g.History = make([]Action, 0)
+	g.Runouts = nil
+	g.Payouts = nil
+	g.Turn = NO_TURN
+	g.lastRaise = g.minRaiseSize()
+	g.raises = 0
+
+	inHand := 0
+	for _, p := range g.Players {
+		p.Hand = NO_CARD
+		p.BetCoins = 0
+		p.TotalBetCoins = 0
+		p.HasFolded = p.Coins == 0
+		p.HasChecked = false
+		p.HasActed = false
+		p.HasShown = false
+		p.reopenBet = 0
+
+		if !p.HasFolded {
+			inHand++
+		}
+	}
+
+	if inHand < 2 {
+		g.handOver = true
+		return errNotEnoughPlayers
+	}
+	g.handOver = false
+
+	if g.Button < 0 || g.Button >= len(g.Players) {
+		g.Button = 0
+	}
+	if g.Players[g.Button].HasFolded {
+		g.Button = g.nextSeat(g.Button, isInHand)
+	}
+
+	for _, p := range g.Players {
+		if !p.HasFolded {
+			ante := minUint(g.Ante, p.Coins)
+			p.Coins -= ante
+			p.TotalBetCoins += ante
+			g.Pot += ante
+		}
+	}
+
+	// Heads-up the button posts the small blind
+	sbSeat := g.Button
+	if inHand > 2 {
+		sbSeat = g.nextSeat(g.Button, isInHand)
+	}
+	bbSeat := g.nextSeat(sbSeat, isInHand)
+	g.putCoins(g.Players[sbSeat], g.SmallBlind)
+	g.putCoins(g.Players[bbSeat], g.BigBlind)
+	if g.BigBlind > 0 {
+		g.raises = 1
+	}
+
+	if err := g.DealCards(); err != nil {
+		return err
+	}
+
+	g.Turn = g.nextToAct(bbSeat)
+	if g.Turn == NO_TURN {
+		return g.endRound()
+	}
+
+	return nil
+}
+
+// HandIsOver returns true when the pots of the hand have been awarded, so a new hand has to be started.
+func (g *Game) HandIsOver() bool {
+	return g.handOver
+}
+
+// LegalActions returns the actions the player in Turn can do.
+// The Amount of a BET or a RAISE is the minimum one, and if the player can bet more,
+// another BET or RAISE with the maximum Amount (all-in) is returned too.
+// Any Amount between both is legal.
+func (g *Game) LegalActions() []Action {
+	if g.handOver || g.Turn == NO_TURN {
+		return nil
+	}
+
+	p := g.Players[g.Turn]
+	state := g.Board.State
+	currentBet := g.CurrentBet()
+	actions := make([]Action, 0, 4)
+
+	if p.BetCoins < currentBet {
+		actions = append(actions,
+			Action{Seat: g.Turn, Kind: FOLD, Amount: p.BetCoins, State: state},
+			Action{Seat: g.Turn, Kind: CALL, Amount: minUint(currentBet, p.BetCoins+p.Coins), State: state},
+		)
+	} else {
+		actions = append(actions, Action{Seat: g.Turn, Kind: CHECK, Amount: p.BetCoins, State: state})
+	}
+
+	if min, max, ok := g.raiseLimits(p); ok {
+		kind := RAISE
+		if currentBet == 0 {
+			kind = BET
+		}
+
+		actions = append(actions, Action{Seat: g.Turn, Kind: kind, Amount: min, State: state})
+		if max > min {
+			actions = append(actions, Action{Seat: g.Turn, Kind: kind, Amount: max, State: state})
+		}
+	}
+
+	return actions
+}
+
+// Apply does the action for the player in Turn, and moves the game forward:
+// to the next player, to the next BoardState when the betting round finishes,
+// and awards the pots when the hand is over.
+// Returns an error if the action is not one of the LegalActions.
+func (g *Game) Apply(a Action) error {
+	if g.handOver {
+		return errHandIsOver
+	}
+	if a.Seat != g.Turn || g.Turn == NO_TURN {
+		return errNotPlayerTurn
+	}
+
+	p := g.Players[a.Seat]
+	currentBet := g.CurrentBet()
+
+	switch a.Kind {
+	case FOLD:
+		if p.BetCoins >= currentBet {
+			return errIllegalAction
+		}
+		p.HasFolded = true
+		a.Amount = p.BetCoins
+	case CHECK:
+		if p.BetCoins < currentBet {
+			return errIllegalAction
+		}
+		p.HasChecked = true
+		a.Amount = p.BetCoins
+	case CALL:
+		if p.BetCoins >= currentBet {
+			return errIllegalAction
+		}
+		a.Amount = minUint(currentBet, p.BetCoins+p.Coins)
+		g.putCoins(p, a.Amount-p.BetCoins)
+	case BET, RAISE:
+		min, max, ok := g.raiseLimits(p)
+		if !ok || (a.Kind == BET) != (currentBet == 0) || a.Amount < min || a.Amount > max {
+			return errIllegalAction
+		}
+
+		if raise := a.Amount - currentBet; raise > g.lastRaise {
+			g.lastRaise = raise
+		}
+		g.raises++
+		g.putCoins(p, a.Amount-p.BetCoins)
+
+		for _, opponent := range g.Players {
+			opponent.HasActed = false
+		}
+	default:
+		return errIllegalAction
+	}
+
+	p.HasActed = true
+	p.reopenBet = g.CurrentBet() + g.lastRaise
+	a.State = g.Board.State
+	g.History = append(g.History, a)
+
+	return g.nextTurn()
+}
+
+// Pots divides the coins bet in the hand into the main pot and the side pots,
+// depending on how many coins each player has bet.
+func (g *Game) Pots() []SidePot {
+	levels := make([]uint, 0, len(g.Players))
+	for _, p := range g.Players {
+		if !p.HasFolded && p.TotalBetCoins > 0 {
+			levels = append(levels, p.TotalBetCoins)
+		}
+	}
+	sort.Slice(levels, func(i, j int) bool { return levels[i] < levels[j] })
+
+	pots := make([]SidePot, 0, len(levels))
+	var prevLevel uint
+	for _, level := range levels {
+		if level == prevLevel {
+			continue
+		}
+
+		pot := SidePot{}
+		for _, p := range g.Players {
+			pot.Coins += minUint(p.TotalBetCoins, level) - minUint(p.TotalBetCoins, prevLevel)
+			if !p.HasFolded && p.TotalBetCoins >= level {
+				pot.Players = append(pot.Players, p)
+			}
+		}
+
+		pots = append(pots, pot)
+		prevLevel = level
+	}
+
+	// Coins bet by folded players above every other bet go to the last pot
+	if len(pots) > 0 {
+		for _, p := range g.Players {
+			if p.TotalBetCoins > prevLevel {
+				pots[len(pots)-1].Coins += p.TotalBetCoins - prevLevel
+			}
+		}
+	}
+
+	return pots
+}
+
+// nextTurn gives the turn to the next player, or finishes the round if nobody has to act.
+func (g *Game) nextTurn() error {
+	if g.playersInHand() < 2 {
+		return g.endHand()
+	}
+
+	g.Turn = g.nextToAct(g.Turn)
+	if g.Turn == NO_TURN {
+		return g.endRound()
+	}
+
+	return nil
+}
+
+// endRound returns the uncalled bet, collects the bets into the pot, and goes to the next BoardState.
+// If less than two players can bet, the board goes on until the SHOWDOWN without asking for actions.
+func (g *Game) endRound() error {
+	for g.Board.State != SHOWDOWN {
+		g.returnUncalledBet()
+		g.collectBets()
+
+		ranOut, err := g.runOut()
+		if err != nil {
+			return err
+		}
+		if ranOut {
+			break
+		}
+		if err := g.Board.NextBoardState(); err != nil {
+			return err
+		}
+		if g.Board.State == SHOWDOWN {
+			break
+		}
+
+		g.Turn = g.nextToAct(g.Button)
+		if g.Turn != NO_TURN {
+			return nil
+		}
+	}
+
+	return g.endHand()
+}
+
+// endHand returns the uncalled bet, decides who shows the cards in the SHOWDOWN (see showdown),
+// and awards every pot to the best hands which haven't been mucked (see awardPot and SplitPot),
+// or splits every pot between the Runouts if the board has been run more than once.
+func (g *Game) endHand() error {
+	g.returnUncalledBet()
+	showdown := g.Board.State == SHOWDOWN && g.playersInHand() >= 2
+	if showdown {
+		g.showdown()
+	}
+
+	for i, pot := range g.Pots() {
+		players := g.contenders(pot, showdown)
+		if len(g.Runouts) == 0 {
+			g.awardPot(i, 0, pot.Coins, players, JoinCards(g.Board.TableCards...))
+			continue
+		}
+
+		// Each runout wins a part of the pot, and the odd coins go to the first runouts
+		times := uint(len(g.Runouts))
+		for r, runout := range g.Runouts {
+			coins := pot.Coins / times
+			if uint(r) < pot.Coins%times {
+				coins++
+			}
+
+			for _, payout := range g.awardPot(i, r, coins, players, JoinCards(runout.TableCards...)) {
+				runout.Won[g.Seat(payout.Player)] += payout.Coins
+			}
+		}
+	}
+
+	g.collectBets()
+	g.Pot = 0
+	g.Turn = NO_TURN
+	g.handOver = true
+
+	return nil
+}
+
+// awardPot gives the coins to the players with the best hand (splitting them with SplitPot if there is a tie),
+// adds the payouts to Payouts, and returns them.
+func (g *Game) awardPot(pot, runout int, coins uint, players []*Player, tableCards Cards) []Payout {
+	winners := players
+	if len(winners) > 1 {
+		winners = g.Board.variant().winners(tableCards, players)
+	}
+
+	values := make([]PlayerHandValue, len(winners))
+	for i, winner := range winners {
+		values[i] = PlayerHandValue{Player: winner}
+		if g.Board.variant().HandValue == nil {
+			values[i].BestHand, values[i].HandKind = BestHand(winner, tableCards)
+		}
+	}
+
+	payouts := g.SplitPot(coins, values)
+	for i := range payouts {
+		payouts[i].Pot, payouts[i].Runout = pot, runout
+		payouts[i].Player.Coins += payouts[i].Coins
+	}
+	g.Payouts = append(g.Payouts, payouts...)
+
+	return payouts
+}
+
+// returnUncalledBet gives back to the player with the highest bet of the round the coins nobody has matched
+// (because everybody else folded or is all-in for less).
+func (g *Game) returnUncalledBet() {
+	var highest, second uint
+	var bettor *Player
+	for _, p := range g.Players {
+		switch {
+		case p.BetCoins > highest:
+			highest, second, bettor = p.BetCoins, highest, p
+		case p.BetCoins > second:
+			second = p.BetCoins
+		}
+	}
+
+	uncalled := highest - second
+	if bettor != nil && uncalled > 0 {
+		bettor.BetCoins -= uncalled
+		bettor.TotalBetCoins -= uncalled
+		bettor.Coins += uncalled
+	}
+}
+
+// collectBets moves the bets of the round into the pot, and prepares players for the next round.
+func (g *Game) collectBets() {
+	for _, p := range g.Players {
+		g.Pot += p.BetCoins
+		p.BetCoins = 0
+		p.HasChecked = false
+		p.HasActed = false
+		p.reopenBet = 0
+	}
+
+	g.lastRaise = g.minRaiseSize()
+	g.raises = 0
+}
+
+// putCoins moves coins from the player stack to its bet, or all its coins if it hasn't enough.
+func (g *Game) putCoins(p *Player, coins uint) {
+	coins = minUint(coins, p.Coins)
+	p.Coins -= coins
+	p.BetCoins += coins
+	p.TotalBetCoins += coins
+}
+
+// raiseLimits returns the minimum and the maximum coins the player can have bet after raising,
+// and false if the player can't raise.
+//
+// A player who has already acted in the round can only raise again if the bet has been raised
+// at least a full raise since then (LastRaise when it acted). So an all-in for less than a full raise
+// doesn't reopen the betting, unless it completes a full raise with other all-ins.
+func (g *Game) raiseLimits(p *Player) (min, max uint, ok bool) {
+	currentBet := g.CurrentBet()
+	if p.BetCoins+p.Coins <= currentBet || currentBet < p.reopenBet {
+		return 0, 0, false
+	}
+
+	opponentsCanAct := false
+	for _, opponent := range g.Players {
+		if opponent != p && canAct(opponent) {
+			opponentsCanAct = true
+			break
+		}
+	}
+	if !opponentsCanAct {
+		return 0, 0, false
+	}
+
+	return g.structure().RaiseLimits(g, p)
+}
+
+// structure returns the BettingStructure of the game, NoLimit by default.
+func (g *Game) structure() BettingStructure {
+	if g.Structure == nil {
+		return NoLimit{}
+	}
+
+	return g.Structure
+}
+
+// LastRaise returns the size of the last full raise in the round (the biggest one),
+// or the big blind if nobody has raised yet. All-ins for less than that are not full raises.
+func (g *Game) LastRaise() uint {
+	return g.lastRaise
+}
+
+// Raises returns how many bets and raises have been done in the round (the big blind counts as a bet).
+func (g *Game) Raises() int {
+	return g.raises
+}
+
+// CurrentBet returns the highest bet in the round.
+func (g *Game) CurrentBet() uint {
+	var bet uint
+	for _, p := range g.Players {
+		if p.BetCoins > bet {
+			bet = p.BetCoins
+		}
+	}
+
+	return bet
+}
+
+// TotalPot returns the coins in the pot, including the bets of the current round.
+func (g *Game) TotalPot() uint {
+	pot := g.Pot
+	for _, p := range g.Players {
+		pot += p.BetCoins
+	}
+
+	return pot
+}
+
+// minRaiseSize is the minimum bet, and the minimum raise when nobody has raised yet.
+func (g *Game) minRaiseSize() uint {
+	if g.BigBlind == 0 {
+		return 1
+	}
+
+	return g.BigBlind
+}
+
+// nextToAct returns the first seat after `from` whose player has to act, or NO_TURN if nobody has to.
+func (g *Game) nextToAct(from int) int {
+	currentBet := g.CurrentBet()
+	canBet := g.playersAbleToAct() >= 2
+
+	return g.nextSeat(from, func(p *Player) bool {
+		if !canAct(p) {
+			return false
+		}
+
+		return p.BetCoins < currentBet || (!p.HasActed && canBet)
+	})
+}
+
+// nextSeat returns the first seat after `from` (going around the table) whose player matches cond,
+// or NO_TURN if nobody matches.
+func (g *Game) nextSeat(from int, cond func(p *Player) bool) int {
+	n := len(g.Players)
+	for i := 1; i <= n; i++ {
+		seat := (from + i) % n
+		if cond(g.Players[seat]) {
+			return seat
+		}
+	}
+
+	return NO_TURN
+}
+
+// sortFromButton sorts the players by their position, starting from the first one to the left of the button.
+func (g *Game) sortFromButton(players []*Player) {
+	n := len(g.Players)
+	distance := func(p *Player) int {
+		return (g.Seat(p) - g.Button - 1 + n) % n
+	}
+
+	sort.SliceStable(players, func(i, j int) bool {
+		return distance(players[i]) < distance(players[j])
+	})
+}
+
+// playersInHand returns how many players have not folded.
+func (g *Game) playersInHand() int {
+	count := 0
+	for _, p := range g.Players {
+		if isInHand(p) {
+			count++
+		}
+	}
+
+	return count
+}
+
+// playersAbleToAct returns how many players have not folded and still have coins to bet.
+func (g *Game) playersAbleToAct() int {
+	count := 0
+	for _, p := range g.Players {
+		if canAct(p) {
+			count++
+		}
+	}
+
+	return count
+}
+
+// isInHand returns true if the player has not folded.
+func isInHand(p *Player) bool {
+	return !p.HasFolded
+}
+
+// canAct returns true if the player has not folded and is not all-in.
+func canAct(p *Player) bool {
+	return !p.HasFolded && p.Coins > 0
+}
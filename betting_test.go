@@ -0,0 +1,434 @@
+package poker_test
+
+import (
+	"testing"
+
+	"github.com/arturo-source/poker-engine"
+)
+
+func newBettingGame(coins ...uint) *poker.Game {
+	g := poker.NewGame()
+	g.SmallBlind = 1
+	g.BigBlind = 2
+
+	for i, c := range coins {
+		p := poker.NewPlayer(string(rune('A' + i)))
+		p.Coins = c
+		g.Players = append(g.Players, p)
+	}
+
+	return g
+}
+
+func totalCoins(g *poker.Game) uint {
+	total := g.Pot
+	for _, p := range g.Players {
+		total += p.Coins + p.BetCoins
+	}
+
+	return total
+}
+
+func mustApply(t *testing.T, g *poker.Game, kind poker.ActionKind, amount uint) {
+	t.Helper()
+
+	err := g.Apply(poker.Action{Seat: g.Turn, Kind: kind, Amount: amount})
+	if err != nil {
+		t.Fatalf("Unexpected error applying %s %d: %s", kind, amount, err)
+	}
+}
+
+func TestStartHandPostsBlinds(t *testing.T) {
+	g := newBettingGame(100, 100, 100)
+
+	err := g.StartHand()
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	if g.Players[1].BetCoins != 1 || g.Players[2].BetCoins != 2 {
+		t.Errorf("Wrong blinds: %d %d", g.Players[1].BetCoins, g.Players[2].BetCoins)
+	}
+
+	want := 0
+	got := g.Turn
+	if want != got {
+		t.Errorf("\nWant %d\nGot  %d", want, got)
+	}
+
+	for _, p := range g.Players {
+		if p.Hand.Count() != 2 {
+			t.Errorf("Player %s has %d cards", p.Name, p.Hand.Count())
+		}
+	}
+}
+
+func TestHeadsUpButtonActsFirstPreflop(t *testing.T) {
+	g := newBettingGame(100, 100)
+	g.Button = 1
+
+	err := g.StartHand()
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	if g.Players[1].BetCoins != 1 || g.Players[0].BetCoins != 2 {
+		t.Errorf("Wrong blinds: %d %d", g.Players[1].BetCoins, g.Players[0].BetCoins)
+	}
+
+	want := 1
+	got := g.Turn
+	if want != got {
+		t.Errorf("\nWant %d\nGot  %d", want, got)
+	}
+}
+
+func TestNotEnoughPlayers(t *testing.T) {
+	g := newBettingGame(100, 0)
+
+	err := g.StartHand()
+	if err == nil {
+		t.Errorf("Wanted an error. Got nil.")
+	}
+}
+
+func TestLegalActionsPreflop(t *testing.T) {
+	g := newBettingGame(100, 100, 100)
+	g.StartHand()
+
+	want := []poker.Action{
+		{Seat: 0, Kind: poker.FOLD, Amount: 0},
+		{Seat: 0, Kind: poker.CALL, Amount: 2},
+		{Seat: 0, Kind: poker.RAISE, Amount: 4},
+		{Seat: 0, Kind: poker.RAISE, Amount: 100},
+	}
+	got := g.LegalActions()
+	if len(want) != len(got) {
+		t.Fatalf("\nWant %v\nGot  %v", want, got)
+	}
+	for i := range want {
+		if want[i] != got[i] {
+			t.Errorf("\nWant %v\nGot  %v", want[i], got[i])
+		}
+	}
+}
+
+func TestIllegalActions(t *testing.T) {
+	g := newBettingGame(100, 100, 100)
+	g.StartHand()
+
+	illegal := []poker.Action{
+		{Seat: 1, Kind: poker.CALL},
+		{Seat: 0, Kind: poker.CHECK},
+		{Seat: 0, Kind: poker.BET, Amount: 10},
+		{Seat: 0, Kind: poker.RAISE, Amount: 3},
+		{Seat: 0, Kind: poker.RAISE, Amount: 101},
+	}
+	for _, a := range illegal {
+		if err := g.Apply(a); err == nil {
+			t.Errorf("Wanted an error applying %v. Got nil.", a)
+		}
+	}
+}
+
+func TestEverybodyFoldsToRaise(t *testing.T) {
+	g := newBettingGame(100, 100, 100)
+	g.StartHand()
+
+	mustApply(t, g, poker.RAISE, 6)
+	mustApply(t, g, poker.FOLD, 0)
+	mustApply(t, g, poker.FOLD, 0)
+
+	if !g.HandIsOver() {
+		t.Fatalf("Hand should be over")
+	}
+
+	want := uint(103)
+	got := g.Players[0].Coins
+	if want != got {
+		t.Errorf("\nWant %d\nGot  %d", want, got)
+	}
+	if totalCoins(g) != 300 {
+		t.Errorf("Coins are not conserved: %d", totalCoins(g))
+	}
+}
+
+func TestRoundsGoToShowdown(t *testing.T) {
+	g := newBettingGame(100, 100)
+	g.StartHand()
+
+	mustApply(t, g, poker.CALL, 0)
+	mustApply(t, g, poker.CHECK, 0)
+	if g.Board.State != poker.FLOP || g.Pot != 4 {
+		t.Fatalf("Wrong state after preflop: %v %d", g.Board.State, g.Pot)
+	}
+
+	// Postflop the first to act is the big blind (left of the button)
+	if g.Turn != 1 {
+		t.Errorf("\nWant %d\nGot  %d", 1, g.Turn)
+	}
+
+	for !g.HandIsOver() {
+		mustApply(t, g, poker.CHECK, 0)
+	}
+
+	if g.Board.State != poker.SHOWDOWN {
+		t.Errorf("\nWant %v\nGot  %v", poker.SHOWDOWN, g.Board.State)
+	}
+	if totalCoins(g) != 200 {
+		t.Errorf("Coins are not conserved: %d", totalCoins(g))
+	}
+}
+
+func TestAllInRunsTheBoard(t *testing.T) {
+	g := newBettingGame(50, 100)
+	g.StartHand()
+
+	mustApply(t, g, poker.RAISE, 50)
+	mustApply(t, g, poker.CALL, 0)
+
+	if !g.HandIsOver() {
+		t.Fatalf("Hand should be over")
+	}
+	if len(g.Board.TableCards) != 5 {
+		t.Errorf("\nWant %d\nGot  %d", 5, len(g.Board.TableCards))
+	}
+	if totalCoins(g) != 150 {
+		t.Errorf("Coins are not conserved: %d", totalCoins(g))
+	}
+}
+
+func TestSidePots(t *testing.T) {
+	g := newBettingGame(10, 50, 100)
+	for _, p := range g.Players {
+		p.Coins = 0
+	}
+	g.Players[0].TotalBetCoins = 10
+	g.Players[1].TotalBetCoins = 50
+	g.Players[2].TotalBetCoins = 100
+
+	pots := g.Pots()
+	want := []uint{30, 80, 50}
+	if len(want) != len(pots) {
+		t.Fatalf("\nWant %d pots\nGot  %d", len(want), len(pots))
+	}
+	for i := range want {
+		if want[i] != pots[i].Coins {
+			t.Errorf("\nWant %d\nGot  %d", want[i], pots[i].Coins)
+		}
+		if len(pots[i].Players) != 3-i {
+			t.Errorf("Pot %d has %d players", i, len(pots[i].Players))
+		}
+	}
+}
+
+func TestSidePotsWithFoldedPlayer(t *testing.T) {
+	g := newBettingGame(10, 50, 100)
+	g.Players[0].TotalBetCoins = 10
+	g.Players[1].TotalBetCoins = 40
+	g.Players[1].HasFolded = true
+	g.Players[2].TotalBetCoins = 40
+
+	pots := g.Pots()
+	if len(pots) != 2 || pots[0].Coins != 30 || pots[1].Coins != 60 {
+		t.Errorf("Wrong pots: %v", pots)
+	}
+}
+
+func TestShortAllInDoesntReopenBetting(t *testing.T) {
+	g := newBettingGame(1000, 1000, 15)
+	g.StartHand()
+
+	mustApply(t, g, poker.RAISE, 10)
+	mustApply(t, g, poker.CALL, 10)
+	// Raises 5, less than the 8 of the last full raise
+	mustApply(t, g, poker.RAISE, 15)
+
+	if g.LastRaise() != 8 {
+		t.Errorf("\nWant %d\nGot  %d", 8, g.LastRaise())
+	}
+
+	for _, seat := range []int{0, 1} {
+		if g.Turn != seat {
+			t.Fatalf("\nWant turn %d\nGot  %d", seat, g.Turn)
+		}
+		if _, _, ok := raiseAmounts(g); ok {
+			t.Errorf("Seat %d already acted, and shouldn't be able to raise: %v", seat, g.LegalActions())
+		}
+		if err := g.Apply(poker.Action{Seat: seat, Kind: poker.RAISE, Amount: 30}); err == nil {
+			t.Errorf("Wanted an error raising. Got nil.")
+		}
+		mustApply(t, g, poker.CALL, 15)
+	}
+
+	if g.Board.State != poker.FLOP {
+		t.Errorf("\nWant %d\nGot  %d", poker.FLOP, g.Board.State)
+	}
+}
+
+func TestFullAllInReopensBetting(t *testing.T) {
+	g := newBettingGame(1000, 1000, 18)
+	g.StartHand()
+
+	mustApply(t, g, poker.RAISE, 10)
+	mustApply(t, g, poker.CALL, 10)
+	mustApply(t, g, poker.RAISE, 18)
+
+	checkRaiseAmounts(t, g, 26, 1000)
+}
+
+func TestShortAllInsAddingUpToAFullRaise(t *testing.T) {
+	g := newBettingGame(14, 20, 1000, 1000)
+	g.StartHand()
+
+	// Seat 3 raises 8, then two short all-ins: to 14 (raises 4) and to 20 (raises 6)
+	mustApply(t, g, poker.RAISE, 10)
+	mustApply(t, g, poker.RAISE, 14)
+	mustApply(t, g, poker.RAISE, 20)
+
+	// The big blind hasn't acted, the minimum raise is still 8
+	if g.Turn != 2 {
+		t.Fatalf("\nWant turn %d\nGot  %d", 2, g.Turn)
+	}
+	checkRaiseAmounts(t, g, 28, 1000)
+	mustApply(t, g, poker.CALL, 20)
+
+	// Seat 3 faces 10 more than its raise, which is more than a full raise
+	if g.Turn != 3 {
+		t.Fatalf("\nWant turn %d\nGot  %d", 3, g.Turn)
+	}
+	checkRaiseAmounts(t, g, 28, 1000)
+}
+
+func TestShortAllInAfterReraise(t *testing.T) {
+	g := newBettingGame(1000, 40, 1000, 1000)
+	g.StartHand()
+
+	// Raise to 10 (8), reraise to 30 (20), and all-in to 40 (10, not a full raise)
+	mustApply(t, g, poker.RAISE, 10)
+	mustApply(t, g, poker.RAISE, 30)
+	mustApply(t, g, poker.RAISE, 40)
+	mustApply(t, g, poker.FOLD, 2)
+
+	// Seat 3 raised to 10, and the bet has gone up 30 since then, so it can raise
+	if g.Turn != 3 {
+		t.Fatalf("\nWant turn %d\nGot  %d", 3, g.Turn)
+	}
+	checkRaiseAmounts(t, g, 60, 1000)
+	mustApply(t, g, poker.CALL, 40)
+
+	// Seat 0 reraised to 30, and the bet has only gone up 10
+	if _, _, ok := raiseAmounts(g); ok {
+		t.Errorf("Seat 0 shouldn't be able to raise: %v", g.LegalActions())
+	}
+}
+
+func TestShortAllInPostflop(t *testing.T) {
+	g := newBettingGame(1000, 1000, 30)
+	g.StartHand()
+
+	mustApply(t, g, poker.CALL, 2)
+	mustApply(t, g, poker.CALL, 2)
+	mustApply(t, g, poker.CHECK, 2)
+
+	// Small blind bets 20, big blind all-in for 28
+	mustApply(t, g, poker.BET, 20)
+	mustApply(t, g, poker.RAISE, 28)
+
+	// The button hasn't acted: it can raise a full raise over the all-in
+	if g.Turn != 0 {
+		t.Fatalf("\nWant turn %d\nGot  %d", 0, g.Turn)
+	}
+	checkRaiseAmounts(t, g, 48, 998)
+	mustApply(t, g, poker.CALL, 28)
+
+	// The small blind bet 20, and the bet only went up 8
+	if _, _, ok := raiseAmounts(g); ok {
+		t.Errorf("The small blind shouldn't be able to raise: %v", g.LegalActions())
+	}
+	mustApply(t, g, poker.CALL, 28)
+
+	if g.Board.State != poker.TURN || totalCoins(g) != 2030 {
+		t.Errorf("Wrong state %d, or coins not conserved: %d", g.Board.State, totalCoins(g))
+	}
+}
+
+func TestShortAllInInFixedLimit(t *testing.T) {
+	g := newBettingGame(1000, 1000, 5)
+	g.Structure = poker.FixedLimit{SmallBet: 2, BigBet: 4, BigBetState: poker.FLOP}
+	g.StartHand()
+
+	mustApply(t, g, poker.RAISE, 4)
+	mustApply(t, g, poker.CALL, 4)
+	mustApply(t, g, poker.RAISE, 5)
+
+	if _, _, ok := raiseAmounts(g); ok {
+		t.Errorf("A short all-in shouldn't reopen the betting: %v", g.LegalActions())
+	}
+}
+
+func TestUncalledBetIsReturned(t *testing.T) {
+	g := newBettingGame(100, 100, 100)
+	g.StartHand()
+
+	mustApply(t, g, poker.RAISE, 20)
+	mustApply(t, g, poker.FOLD, 1)
+	mustApply(t, g, poker.FOLD, 2)
+
+	if !g.HandIsOver() {
+		t.Fatalf("Hand should be over")
+	}
+
+	want := []uint{103, 99, 98}
+	for i, p := range g.Players {
+		if want[i] != p.Coins {
+			t.Errorf("Seat %d\nWant %d\nGot  %d", i, want[i], p.Coins)
+		}
+	}
+	if g.Players[0].TotalBetCoins != 2 {
+		t.Errorf("Only the called 2 coins should be bet: %d", g.Players[0].TotalBetCoins)
+	}
+}
+
+func TestUncalledAllInIsReturned(t *testing.T) {
+	g := newBettingGame(100, 30)
+	g.StartHand()
+
+	mustApply(t, g, poker.RAISE, 100)
+	mustApply(t, g, poker.CALL, 30)
+
+	if !g.HandIsOver() || len(g.Board.TableCards) != 5 {
+		t.Fatalf("The board should have been run to the showdown")
+	}
+	for _, a := range g.History[2:] {
+		if a.State != poker.SHOWDOWN {
+			t.Errorf("Nobody should have acted after the all-in: %v", g.History)
+		}
+	}
+	if g.Players[0].TotalBetCoins != 30 {
+		t.Errorf("\nWant %d\nGot  %d", 30, g.Players[0].TotalBetCoins)
+	}
+	if g.Players[0].Coins < 70 || totalCoins(g) != 130 {
+		t.Errorf("The 70 coins not called should be returned: %d, total %d", g.Players[0].Coins, totalCoins(g))
+	}
+}
+
+func TestAllInOnTheFlopRunsTheBoard(t *testing.T) {
+	g := newBettingGame(100, 100, 60)
+	g.StartHand()
+
+	mustApply(t, g, poker.CALL, 2)
+	mustApply(t, g, poker.CALL, 2)
+	mustApply(t, g, poker.CHECK, 2)
+
+	mustApply(t, g, poker.BET, 98)
+	mustApply(t, g, poker.CALL, 58)
+	mustApply(t, g, poker.CALL, 98)
+
+	if !g.HandIsOver() || len(g.Board.TableCards) != 5 {
+		t.Fatalf("The board should have been run to the showdown")
+	}
+	if totalCoins(g) != 260 {
+		t.Errorf("Coins are not conserved: %d", totalCoins(g))
+	}
+}
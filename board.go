@@ -13,6 +13,7 @@ const (
 // Board represents a table where you can access to the current flipped cards, burned cards, and the board state (preflop, flop, etc.).
 type Board struct {
 	deck        *Deck
+	rules       *Variant
 	TableCards  []Cards
 	BurnedCards []Cards
 	State       BoardState
@@ -20,12 +21,27 @@ type Board struct {
 
 // NewBoard creates a board with an specific deck, and sets the boards as the initial state.
 func NewBoard(d *Deck) *Board {
-	b := Board{deck: d}
+	return NewVariantBoard(d, HOLDEM)
+}
+
+// NewVariantBoard creates a board with an specific deck, which flips the table cards as the variant says,
+// and sets the boards as the initial state.
+func NewVariantBoard(d *Deck, v *Variant) *Board {
+	b := Board{deck: d, rules: v}
 	b.setInitialState()
 
 	return &b
 }
 
+// variant returns the Variant played in the board, HOLDEM by default.
+func (b *Board) variant() *Variant {
+	if b.rules == nil {
+		return HOLDEM
+	}
+
+	return b.rules
+}
+
 // setInitialState shuffles the cards in deck,
 // creates an empty slice for the table cards,
 // another one for the burned ones,
@@ -77,37 +93,31 @@ func (b *Board) Restart() {
 }
 
 // NextBoardState add corresponding cards to TableCards and BurnedCards, depending on the current State.
+// In hold'em: 3 cards in the FLOP, 1 in the TURN and 1 in the RIVER, burning one card before each of them.
+// Other variants flip their own table cards, and go to the SHOWDOWN after their last betting round.
 // Returns an error if there are no more cards in deck, or if you try to get next state in the SHOWDOWN.
 func (b *Board) NextBoardState() error {
-	switch b.State {
-	case PREFLOP:
-		if err := b.burnCard(); err != nil {
-			return err
-		}
+	if b.State >= SHOWDOWN {
+		return errNoCardsToFlip
+	}
 
-		for i := 0; i < 3; i++ {
-			if err := b.showCard(); err != nil {
-				return err
-			}
-		}
-	case FLOP:
-		if err := b.burnCard(); err != nil {
-			return err
-		}
-		if err := b.showCard(); err != nil {
-			return err
-		}
-	case TURN:
+	v := b.variant()
+	round := int(b.State)
+	if round >= len(v.TableCards) {
+		// pass to showdown
+		b.State = SHOWDOWN
+		return nil
+	}
+
+	if v.BurnCards {
 		if err := b.burnCard(); err != nil {
 			return err
 		}
+	}
+	for i := 0; i < v.TableCards[round]; i++ {
 		if err := b.showCard(); err != nil {
 			return err
 		}
-	case RIVER:
-		// pass to showdown
-	default:
-		return errNoCardsToFlip
 	}
 
 	b.State++
@@ -73,9 +73,9 @@ func (c Cards) mergeSuits() Cards {
 }
 
 // expandToAllSuits takes Cards in first suit and replicates to all suits
-// func (c Cards) expandToAllSuits() Cards {
-// 	return c | c<<(13*1) | c<<(13*2) | c<<(13*3)
-// }
+func (c Cards) expandToAllSuits() Cards {
+	return c | c<<(13*1) | c<<(13*2) | c<<(13*3)
+}
 
 // valueWithoutSuit returns the first cards of the same suit found.
 // Use only if your cards are all the same suit!!
@@ -215,6 +215,19 @@ func (c Cards) Split() []Cards {
 	return cards
 }
 
+// Encode returns the cards as MAX_CARDS numbers (one-hot),
+// where the number in position i is 1 if the card in the bit i is present, or 0 if it is not.
+func (c Cards) Encode() []float64 {
+	encoded := make([]float64, MAX_CARDS)
+	for pos := range encoded {
+		if c.HasBit(pos) {
+			encoded[pos] = 1
+		}
+	}
+
+	return encoded
+}
+
 // JoinCards gets a set of cards and joins them into `Cards`.
 func JoinCards(cards ...Cards) Cards {
 	var c Cards
@@ -0,0 +1,422 @@
+package poker
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+)
+
+var (
+	errNoDeals         = errors.New("the solver needs at least one deal")
+	errNotTwoPlayers   = errors.New("exploitability can only be computed for two players")
+	errHandDoesntStart = errors.New("the hand can't be started")
+)
+
+// InfoSet is a decision point of a player, where it can't distinguish between the different game states,
+// with the regrets and the strategy of the player in it.
+// Actions, the current strategy, and the average strategy are aligned (same index, same action).
+type InfoSet struct {
+	Key         string
+	Seat        int
+	Actions     []Action
+	regrets     []float64
+	deltas      []float64 // regrets of the current iteration, added when it finishes
+	strategySum []float64
+}
+
+// Strategy returns the current strategy (regret matching): the probability of doing each action.
+func (is *InfoSet) Strategy() []float64 {
+	strategy := make([]float64, len(is.Actions))
+
+	var total float64
+	for a, regret := range is.regrets {
+		if regret > 0 {
+			strategy[a] = regret
+			total += regret
+		}
+	}
+
+	for a := range strategy {
+		if total > 0 {
+			strategy[a] /= total
+		} else {
+			strategy[a] = 1 / float64(len(strategy))
+		}
+	}
+
+	return strategy
+}
+
+// AverageStrategy returns the average strategy of all the iterations,
+// which is the one that converges to a Nash equilibrium.
+func (is *InfoSet) AverageStrategy() []float64 {
+	strategy := make([]float64, len(is.Actions))
+
+	var total float64
+	for _, s := range is.strategySum {
+		total += s
+	}
+
+	for a := range strategy {
+		if total > 0 {
+			strategy[a] = is.strategySum[a] / total
+		} else {
+			strategy[a] = 1 / float64(len(strategy))
+		}
+	}
+
+	return strategy
+}
+
+// CFRSolver finds an approximate Nash equilibrium of a Game using counterfactual regret minimization.
+//
+// The game is played from the start of a hand, with the players, coins, blinds and button of the Game passed
+// to NewCFRSolver, and the chance is limited to the Deals (each one is the order of the top cards of the deck,
+// and all of them are equally likely), so small games and subgames of hold'em can be solved exactly.
+// Information sets are built from the views of the players (see GameView.InfoSet).
+//
+// With Plus, the solver uses CFR+ (regrets are never negative, and the average strategy is weighted by iteration).
+// Rand is used by Act to choose between actions, if it is nil the global one from math/rand is used.
+type CFRSolver struct {
+	Plus       bool
+	Rand       *rand.Rand
+	iterations int
+	players    int
+	root       []*cfrNode
+	infoSets   map[string]*InfoSet
+}
+
+// cfrNode is a node of the game tree, a decision of a player, or a terminal one when infoSet is nil.
+type cfrNode struct {
+	infoSet  *InfoSet
+	children []*cfrNode
+	utility  []float64
+}
+
+// NewCFRSolver builds the game tree of the game g for every deal.
+// The game should have its players and coins set, but it doesn't have to be started.
+// Returns an error if there are no deals, if the hand can't be started, or if an action of an information set
+// is not legal in one of its game states (the views of the players don't tell apart states with different actions).
+func NewCFRSolver(g *Game, deals [][]Cards, plus bool) (*CFRSolver, error) {
+	if len(deals) == 0 {
+		return nil, errNoDeals
+	}
+
+	s := &CFRSolver{
+		Plus:     plus,
+		players:  len(g.Players),
+		infoSets: make(map[string]*InfoSet),
+	}
+
+	coins := make([]uint, len(g.Players))
+	for i, p := range g.Players {
+		coins[i] = p.Coins
+	}
+
+	for _, deal := range deals {
+		hand := g.Clone()
+		hand.Board.Restart()
+		hand.Deck.stack(deal)
+
+		if err := hand.startHand(); err != nil {
+			return nil, errHandDoesntStart
+		}
+
+		root, err := s.buildTree(hand, coins)
+		if err != nil {
+			return nil, err
+		}
+		s.root = append(s.root, root)
+	}
+
+	return s, nil
+}
+
+// buildTree returns the tree of all the possible actions from the game state,
+// where terminal nodes have the coins won or lost by each player.
+// Returns an error if an action of the information set can't be applied to the game state.
+func (s *CFRSolver) buildTree(g *Game, coins []uint) (*cfrNode, error) {
+	if g.HandIsOver() {
+		utility := make([]float64, len(g.Players))
+		for i, p := range g.Players {
+			utility[i] = float64(p.Coins) - float64(coins[i])
+		}
+		return &cfrNode{utility: utility}, nil
+	}
+
+	view := g.ViewFor(g.Players[g.Turn])
+	key := view.InfoSet()
+	infoSet, found := s.infoSets[key]
+	if !found {
+		actions := g.LegalActions()
+		infoSet = &InfoSet{
+			Key:         key,
+			Seat:        g.Turn,
+			Actions:     actions,
+			regrets:     make([]float64, len(actions)),
+			deltas:      make([]float64, len(actions)),
+			strategySum: make([]float64, len(actions)),
+		}
+		s.infoSets[key] = infoSet
+	}
+
+	node := &cfrNode{infoSet: infoSet}
+	for _, a := range infoSet.Actions {
+		child := g.Clone()
+		if err := child.Apply(a); err != nil {
+			return nil, fmt.Errorf("information set %q, action %v: %w", key, a, err)
+		}
+
+		subtree, err := s.buildTree(child, coins)
+		if err != nil {
+			return nil, err
+		}
+		node.children = append(node.children, subtree)
+	}
+
+	return node, nil
+}
+
+// Iterate runs n iterations of the algorithm, updating the regrets of each player in turns.
+func (s *CFRSolver) Iterate(n int) {
+	chance := 1 / float64(len(s.root))
+
+	for i := 0; i < n; i++ {
+		s.iterations++
+		for traverser := 0; traverser < s.players; traverser++ {
+			for _, root := range s.root {
+				reach := make([]float64, s.players)
+				for p := range reach {
+					reach[p] = 1
+				}
+				s.cfr(root, traverser, reach, chance)
+			}
+
+			// the strategy can't change until every deal has been traversed
+			for _, infoSet := range s.infoSets {
+				if infoSet.Seat == traverser {
+					s.updateRegrets(infoSet)
+				}
+			}
+		}
+	}
+}
+
+// updateRegrets adds the regrets of the iteration to the cumulative ones.
+func (s *CFRSolver) updateRegrets(infoSet *InfoSet) {
+	for a, delta := range infoSet.deltas {
+		infoSet.regrets[a] += delta
+		if s.Plus && infoSet.regrets[a] < 0 {
+			infoSet.regrets[a] = 0
+		}
+		infoSet.deltas[a] = 0
+	}
+}
+
+// Iterations returns how many iterations have been run.
+func (s *CFRSolver) Iterations() int {
+	return s.iterations
+}
+
+// cfr returns the expected utility of the traverser in the node,
+// updating the regrets and the strategy sum of its information sets.
+// reach is the probability of each player to reach the node, and chance the probability of the deal.
+func (s *CFRSolver) cfr(node *cfrNode, traverser int, reach []float64, chance float64) float64 {
+	if node.infoSet == nil {
+		return node.utility[traverser]
+	}
+
+	infoSet := node.infoSet
+	seat := infoSet.Seat
+	strategy := infoSet.Strategy()
+	values := make([]float64, len(node.children))
+
+	var value float64
+	for a, child := range node.children {
+		prevReach := reach[seat]
+		reach[seat] *= strategy[a]
+		values[a] = s.cfr(child, traverser, reach, chance)
+		reach[seat] = prevReach
+
+		value += strategy[a] * values[a]
+	}
+
+	if seat != traverser {
+		return value
+	}
+
+	opponentsReach := chance
+	for p, r := range reach {
+		if p != seat {
+			opponentsReach *= r
+		}
+	}
+
+	weight := 1.0
+	if s.Plus {
+		weight = float64(s.iterations)
+	}
+
+	for a := range node.children {
+		infoSet.deltas[a] += opponentsReach * (values[a] - value)
+		infoSet.strategySum[a] += weight * reach[seat] * strategy[a]
+	}
+
+	return value
+}
+
+// InfoSets returns all the information sets of the game, by key (see GameView.InfoSet).
+func (s *CFRSolver) InfoSets() map[string]*InfoSet {
+	return s.infoSets
+}
+
+// Act makes the solver an Agent that plays the average strategy.
+// If the view is not in the solved game, it checks or calls.
+func (s *CFRSolver) Act(view GameView, legalActions []Action) Action {
+	infoSet, found := s.infoSets[view.InfoSet()]
+	if !found {
+		for _, a := range legalActions {
+			if a.Kind == CHECK || a.Kind == CALL {
+				return a
+			}
+		}
+		return legalActions[0]
+	}
+
+	random := rand.Float64
+	if s.Rand != nil {
+		random = s.Rand.Float64
+	}
+
+	strategy := infoSet.AverageStrategy()
+	r := random()
+	for a, prob := range strategy {
+		r -= prob
+		if r < 0 {
+			return infoSet.Actions[a]
+		}
+	}
+
+	return infoSet.Actions[len(infoSet.Actions)-1]
+}
+
+// Exploitability returns how many coins per hand a best response wins against the average strategy,
+// averaged between both players. It is 0 in a Nash equilibrium.
+// Returns an error if the game is not between two players.
+func (s *CFRSolver) Exploitability() (float64, error) {
+	if s.players != 2 {
+		return 0, errNotTwoPlayers
+	}
+
+	var total float64
+	for seat := 0; seat < s.players; seat++ {
+		total += s.BestResponseValue(seat)
+	}
+
+	return total / 2, nil
+}
+
+// BestResponseValue returns the expected coins won by the player at seat
+// if it plays the best response against the average strategy of the others.
+func (s *CFRSolver) BestResponseValue(seat int) float64 {
+	br := &bestResponse{
+		seat:    seat,
+		nodes:   make(map[*InfoSet][]weightedNode),
+		actions: make(map[*InfoSet]int),
+		values:  make(map[*cfrNode]float64),
+	}
+
+	chance := 1 / float64(len(s.root))
+	for _, root := range s.root {
+		br.collect(root, chance)
+	}
+
+	var value float64
+	for _, root := range s.root {
+		value += chance * br.value(root)
+	}
+
+	return value
+}
+
+// weightedNode is a node with the probability of the opponents and the chance to reach it.
+type weightedNode struct {
+	node   *cfrNode
+	weight float64
+}
+
+// bestResponse computes the best response of the player at seat.
+type bestResponse struct {
+	seat    int
+	nodes   map[*InfoSet][]weightedNode
+	actions map[*InfoSet]int
+	values  map[*cfrNode]float64
+}
+
+// collect saves the nodes of each information set of the best responder, with the probability of reaching them.
+func (br *bestResponse) collect(node *cfrNode, weight float64) {
+	if node.infoSet == nil || weight == 0 {
+		return
+	}
+
+	if node.infoSet.Seat == br.seat {
+		br.nodes[node.infoSet] = append(br.nodes[node.infoSet], weightedNode{node, weight})
+		for _, child := range node.children {
+			br.collect(child, weight)
+		}
+		return
+	}
+
+	strategy := node.infoSet.AverageStrategy()
+	for a, child := range node.children {
+		br.collect(child, weight*strategy[a])
+	}
+}
+
+// value returns the utility of the best responder in the node.
+func (br *bestResponse) value(node *cfrNode) float64 {
+	if node.infoSet == nil {
+		return node.utility[br.seat]
+	}
+	if value, found := br.values[node]; found {
+		return value
+	}
+
+	var value float64
+	if node.infoSet.Seat == br.seat {
+		value = br.value(node.children[br.bestAction(node.infoSet)])
+	} else {
+		strategy := node.infoSet.AverageStrategy()
+		for a, child := range node.children {
+			if strategy[a] > 0 {
+				value += strategy[a] * br.value(child)
+			}
+		}
+	}
+
+	br.values[node] = value
+	return value
+}
+
+// bestAction returns the action that maximizes the utility of the best responder in the information set,
+// weighting each node of the information set by the probability of reaching it.
+func (br *bestResponse) bestAction(infoSet *InfoSet) int {
+	if action, found := br.actions[infoSet]; found {
+		return action
+	}
+
+	best, bestValue := 0, 0.0
+	for a := range infoSet.Actions {
+		var value float64
+		for _, wn := range br.nodes[infoSet] {
+			value += wn.weight * br.value(wn.node.children[a])
+		}
+
+		if a == 0 || value > bestValue {
+			best, bestValue = a, value
+		}
+	}
+
+	br.actions[infoSet] = best
+	return best
+}
@@ -0,0 +1,124 @@
+package poker_test
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/arturo-source/poker-engine"
+)
+
+// randomDeals returns n random orders of the deck.
+func randomDeals(n int, seed int64) [][]poker.Cards {
+	d := poker.NewDeck()
+	d.Rand = rand.New(rand.NewSource(seed))
+
+	deals := make([][]poker.Cards, n)
+	for i := range deals {
+		d.Shuffle()
+		for j := 0; j < poker.TOTAL_CARDS; j++ {
+			deals[i] = append(deals[i], d.GetNextCard())
+		}
+	}
+
+	return deals
+}
+
+// newShortStackGame is a heads-up hold'em game with only 2 big blinds per player.
+func newShortStackGame(players int) *poker.Game {
+	g := poker.NewGame()
+	g.SmallBlind = 1
+	g.BigBlind = 2
+
+	for i := 0; i < players; i++ {
+		p := poker.NewPlayer("")
+		p.Coins = 4
+		g.Players = append(g.Players, p)
+	}
+
+	return g
+}
+
+func TestCFRConverges(t *testing.T) {
+	// Kuhn poker has hidden cards, so the players have to mix their actions to be unexploitable
+	g := poker.NewKuhnGame(poker.NewPlayer("P1"), poker.NewPlayer("P2"))
+	for _, p := range g.Players {
+		p.Coins = 2
+	}
+
+	s, err := poker.NewCFRSolver(g, poker.KUHN.Deals(2), false)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	before, _ := s.Exploitability()
+	s.Iterate(100)
+	middle, _ := s.Exploitability()
+	s.Iterate(900)
+	after, err := s.Exploitability()
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	if middle >= before || after >= middle || after > 0.002 {
+		t.Errorf("Exploitability didn't converge: %f, %f after 100 iterations, %f after 1000", before, middle, after)
+	}
+	if s.Iterations() != 1000 {
+		t.Errorf("\nWant %d\nGot  %d", 1000, s.Iterations())
+	}
+}
+
+func TestCFRStrategiesAreDistributions(t *testing.T) {
+	s, err := poker.NewCFRSolver(newShortStackGame(2), randomDeals(5, 2), false)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	s.Iterate(100)
+
+	if len(s.InfoSets()) == 0 {
+		t.Fatalf("No information sets were built")
+	}
+
+	for key, infoSet := range s.InfoSets() {
+		var total float64
+		for _, prob := range infoSet.AverageStrategy() {
+			total += prob
+		}
+		if total < 0.999 || total > 1.001 {
+			t.Errorf("Strategy of %s sums %f", key, total)
+		}
+	}
+}
+
+func TestCFRSolverIsAnAgent(t *testing.T) {
+	s, err := poker.NewCFRSolver(newShortStackGame(2), randomDeals(5, 3), true)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	s.Iterate(10)
+	s.Rand = rand.New(rand.NewSource(1))
+
+	m := poker.Match{
+		Agents:        []poker.Agent{s, callingAgent{}},
+		Hands:         20,
+		StartingCoins: 4,
+		SmallBlind:    1,
+		BigBlind:      2,
+	}
+	if _, err := m.Run(); err != nil {
+		t.Errorf("Unexpected error: %s", err)
+	}
+}
+
+func TestCFRErrors(t *testing.T) {
+	if _, err := poker.NewCFRSolver(newShortStackGame(2), nil, false); err == nil {
+		t.Errorf("Wanted an error. Got nil.")
+	}
+
+	s, err := poker.NewCFRSolver(newShortStackGame(3), randomDeals(1, 1), false)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if _, err := s.Exploitability(); err == nil {
+		t.Errorf("Wanted an error. Got nil.")
+	}
+}
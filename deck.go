@@ -1,39 +1,60 @@
 package poker
 
-import "math/rand"
+import (
+	"math/rand"
+	"sort"
+)
 
-// Deck represents a deck with the 52 cards,
-// you should always call NewDeck to build a deck.
+// Deck represents a deck with the 52 cards (or less, see NewDeckFrom),
+// you should always call NewDeck or NewDeckFrom to build a deck.
+//
+// Rand is the source used to shuffle the cards, if it is nil the global one from math/rand is used.
 type Deck struct {
+	Rand    *rand.Rand
 	cards   []Cards
 	pointer int
 }
 
 // NewDeck fills the deck with the 52 cards, and returns the reference to this deck.
 func NewDeck() *Deck {
+	return NewDeckFrom(ALL_CARDS)
+}
+
+// NewDeckFrom fills the deck only with the cards passed, useful for games with a restricted deck.
+func NewDeckFrom(cards Cards) *Deck {
 	deck := &Deck{
-		cards: make([]Cards, 0, TOTAL_CARDS),
+		cards: make([]Cards, 0, cards.Count()),
 	}
 
-	for card := Cards(0b1); card < ACES; card <<= 1 {
-		deck.cards = append(deck.cards, card)
+	for pos := 0; pos < MAX_CARDS; pos++ {
+		if cards.HasBit(pos) {
+			deck.cards = append(deck.cards, NO_CARD.SetBit(pos))
+		}
 	}
 
 	return deck
 }
 
-// Shuffle resets the pointer to 0, to start using the deck again, and shuffles the cards to get in a random order.
+// Shuffle resets the pointer to 0, to start using the deck again, and shuffles the cards to get in a random order
+// (every order is equally likely).
+// The cards are sorted before shuffling them, so the order only depends on Rand (the same seed always gives the same deck).
 func (d *Deck) Shuffle() {
 	d.pointer = 0
-	for i := 0; i < TOTAL_CARDS; i++ {
-		j := rand.Intn(TOTAL_CARDS)
+	sort.Slice(d.cards, func(i, j int) bool { return d.cards[i] < d.cards[j] })
+	intn := rand.Intn
+	if d.Rand != nil {
+		intn = d.Rand.Intn
+	}
+
+	for i := len(d.cards) - 1; i > 0; i-- {
+		j := intn(i + 1)
 		d.cards[i], d.cards[j] = d.cards[j], d.cards[i]
 	}
 }
 
 // GetNextCard returns the next card in the deck, and moves the pointer to the next card.
 func (d *Deck) GetNextCard() Cards {
-	if d.pointer > TOTAL_CARDS {
+	if d.pointer >= len(d.cards) {
 		return NO_CARD
 	}
 
@@ -42,3 +63,18 @@ func (d *Deck) GetNextCard() Cards {
 
 	return card
 }
+
+// stack moves the cards to the top of the deck, in that order, and resets the pointer to 0.
+// The rest of the cards keep their relative order after them.
+func (d *Deck) stack(cards []Cards) {
+	top := JoinCards(cards...)
+	rest := make([]Cards, 0, len(d.cards))
+	for _, card := range d.cards {
+		if !card.CardsArePresent(top) {
+			rest = append(rest, card)
+		}
+	}
+
+	d.cards = append(append(d.cards[:0], cards...), rest...)
+	d.pointer = 0
+}
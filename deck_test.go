@@ -1,6 +1,7 @@
 package poker_test
 
 import (
+	"math/rand"
 	"testing"
 
 	"github.com/arturo-source/poker-engine"
@@ -26,3 +27,44 @@ func TestGetTwoCardsFromDeck(t *testing.T) {
 		t.Errorf("\nWant %s\nGot  %s", want, got)
 	}
 }
+
+func TestShuffleIsReproducible(t *testing.T) {
+	d1 := poker.NewDeck()
+	d1.Rand = rand.New(rand.NewSource(7))
+	d2 := poker.NewDeck()
+	d2.Rand = rand.New(rand.NewSource(7))
+
+	d1.Shuffle()
+	d2.GetNextCard()
+	d2.Shuffle()
+	for i := 0; i < poker.TOTAL_CARDS; i++ {
+		if c1, c2 := d1.GetNextCard(), d2.GetNextCard(); c1 != c2 {
+			t.Fatalf("Card %d\nWant %s\nGot  %s", i, c1, c2)
+		}
+	}
+}
+
+func TestShuffleIsUniform(t *testing.T) {
+	c := poker.NewCard
+	d := poker.NewDeckFrom(c("2c") | c("3c") | c("4c"))
+	d.Rand = rand.New(rand.NewSource(1))
+
+	const shuffles = 60000
+	orders := make(map[poker.Cards]int)
+	for i := 0; i < shuffles; i++ {
+		d.Shuffle()
+		// The first two cards are enough to know the order
+		first := d.GetNextCard()
+		second := d.GetNextCard()
+		orders[first|second<<1]++
+	}
+
+	if len(orders) != 6 {
+		t.Fatalf("\nWant %d orders\nGot  %d", 6, len(orders))
+	}
+	for order, n := range orders {
+		if n < shuffles/6-500 || n > shuffles/6+500 {
+			t.Errorf("Order %d appeared %d times, want about %d", order, n, shuffles/6)
+		}
+	}
+}
@@ -0,0 +1,241 @@
+package poker
+
+import (
+	"errors"
+	"math/rand"
+)
+
+var errEnvIsDone = errors.New("the hand is over, call Reset to start another one")
+
+// Discrete actions of Env. The actions between ENV_CHECK_CALL and the last one (all-in)
+// are bets or raises of Env.BetSizes fractions of the pot.
+const (
+	ENV_FOLD = iota
+	ENV_CHECK_CALL
+	ENV_FIRST_BET_SIZE
+)
+
+// Env is a reinforcement learning environment on top of Game, similar to the gym ones.
+// The learning agent sits at Seat, and plays one hand per episode against the Opponents
+// (Opponents[i] sits i+1 seats to the left of the learning agent).
+//
+// Observations are fixed-size slices of float64 (see Observation),
+// and actions are integers from 0 to NumActions()-1 (see ENV_FOLD, ENV_CHECK_CALL, and BetSizes).
+type Env struct {
+	Game          *Game
+	Seat          int
+	Opponents     []Agent
+	StartingCoins uint
+	BetSizes      []float64
+}
+
+// NewEnv creates an environment where the learning agent plays against the opponents,
+// with the learning agent at seat 0, and bet sizes of half pot and pot.
+func NewEnv(opponents []Agent, startingCoins, smallBlind, bigBlind uint) *Env {
+	g := NewGame()
+	g.SmallBlind = smallBlind
+	g.BigBlind = bigBlind
+
+	for i := 0; i <= len(opponents); i++ {
+		g.Players = append(g.Players, NewPlayer(""))
+	}
+
+	return &Env{
+		Game:          g,
+		Seat:          0,
+		Opponents:     opponents,
+		StartingCoins: startingCoins,
+		BetSizes:      []float64{0.5, 1},
+	}
+}
+
+// NumActions returns how many discrete actions there are: fold, check or call, one per bet size, and all-in.
+func (e *Env) NumActions() int {
+	return ENV_FIRST_BET_SIZE + len(e.BetSizes) + 1
+}
+
+// ObservationSize returns the length of the slices returned by Observation.
+func (e *Env) ObservationSize() int {
+	return 2*MAX_CARDS + int(SHOWDOWN) + 1 + 2 + 4*len(e.Game.Players)
+}
+
+// Reset starts a new hand with the stacks reset, and lets the opponents act until it is the turn of the learning agent.
+// Returns the first observation, and like Step, the reward and if the hand is over
+// (the opponents can end it before the learning agent acts, for example folding to its blind).
+// The seed decides the cards and the button, so the same seed always deals the same hand.
+func (e *Env) Reset(seed int64) (observation []float64, reward float64, done bool, err error) {
+	r := rand.New(rand.NewSource(seed))
+	g := e.Game
+
+	g.Deck.Rand = r
+	g.Button = r.Intn(len(g.Players))
+
+	for _, p := range g.Players {
+		p.Coins = e.StartingCoins
+	}
+
+	if err := g.StartHand(); err != nil {
+		return nil, 0, false, err
+	}
+	if err := e.playOpponents(); err != nil {
+		return nil, 0, false, err
+	}
+
+	return e.Observation(), e.reward(), e.Done(), nil
+}
+
+// Step does the action for the learning agent, and lets the opponents act until it is its turn again.
+// Returns the next observation, the reward (coins won or lost in the hand, only when it is over),
+// and if the hand is over.
+func (e *Env) Step(action int) (observation []float64, reward float64, done bool, err error) {
+	if e.Done() {
+		return nil, 0, true, errEnvIsDone
+	}
+
+	a, ok := e.action(action)
+	if !ok {
+		return nil, 0, false, errIllegalAction
+	}
+	if err := e.Game.Apply(a); err != nil {
+		return nil, 0, false, err
+	}
+	if err := e.playOpponents(); err != nil {
+		return nil, 0, false, err
+	}
+
+	return e.Observation(), e.reward(), e.Done(), nil
+}
+
+// Done returns true if the hand is over.
+func (e *Env) Done() bool {
+	return e.Game.HandIsOver()
+}
+
+// reward returns the coins won or lost by the learning agent when the hand is over, and 0 before.
+func (e *Env) reward() float64 {
+	if !e.Done() {
+		return 0
+	}
+
+	return float64(e.Game.Players[e.Seat].Coins) - float64(e.StartingCoins)
+}
+
+// ActionMask returns which of the discrete actions are legal now.
+func (e *Env) ActionMask() []bool {
+	mask := make([]bool, e.NumActions())
+	if e.Done() || e.Game.Turn != e.Seat {
+		return mask
+	}
+
+	for action := range mask {
+		_, mask[action] = e.action(action)
+	}
+
+	return mask
+}
+
+// Observation encodes what the learning agent sees into numbers:
+// its hand and the table cards (52 one-hot each, see Cards.Encode), the BoardState (one-hot),
+// the pot and the coins to call, and for each seat starting from the agent:
+// the stack, the bet, if it has folded, and if it has the button.
+// Coins are divided by StartingCoins.
+func (e *Env) Observation() []float64 {
+	g := e.Game
+	view := g.ViewFor(g.Players[e.Seat])
+	obs := make([]float64, 0, e.ObservationSize())
+	coins := func(c uint) float64 {
+		if e.StartingCoins == 0 {
+			return float64(c)
+		}
+		return float64(c) / float64(e.StartingCoins)
+	}
+
+	obs = append(obs, view.Hand.Encode()...)
+	obs = append(obs, JoinCards(view.TableCards...).Encode()...)
+
+	states := make([]float64, SHOWDOWN+1)
+	states[view.State] = 1
+	obs = append(obs, states...)
+
+	obs = append(obs, coins(g.TotalPot()), coins(g.CurrentBet()-view.Players[e.Seat].BetCoins))
+
+	n := len(view.Players)
+	for i := 0; i < n; i++ {
+		seat := (e.Seat + i) % n
+		p := view.Players[seat]
+		obs = append(obs, coins(p.Coins), coins(p.BetCoins), boolToFloat(p.HasFolded), boolToFloat(seat == g.Button))
+	}
+
+	return obs
+}
+
+// action translates a discrete action into a Game Action, and returns false if it is not legal.
+func (e *Env) action(action int) (Action, bool) {
+	g := e.Game
+	p := g.Players[e.Seat]
+	legalActions := g.LegalActions()
+
+	find := func(kinds ...ActionKind) (Action, bool) {
+		for _, a := range legalActions {
+			for _, kind := range kinds {
+				if a.Kind == kind {
+					return a, true
+				}
+			}
+		}
+		return Action{}, false
+	}
+
+	switch {
+	case action == ENV_FOLD:
+		return find(FOLD)
+	case action == ENV_CHECK_CALL:
+		return find(CHECK, CALL)
+	case action < ENV_FIRST_BET_SIZE || action >= e.NumActions():
+		return Action{}, false
+	}
+
+	a, ok := find(BET, RAISE)
+	min, max, _ := g.raiseLimits(p)
+	if !ok {
+		return Action{}, false
+	}
+
+	// all-in
+	if action == e.NumActions()-1 {
+		a.Amount = max
+		return a, true
+	}
+
+	a.Amount = potBet(g, e.BetSizes[action-ENV_FIRST_BET_SIZE])
+	if a.Amount < min {
+		a.Amount = min
+	}
+	if a.Amount >= max {
+		return Action{}, false
+	}
+
+	return a, true
+}
+
+// playOpponents asks the opponents for actions until it is the turn of the learning agent, or the hand is over.
+func (e *Env) playOpponents() error {
+	g := e.Game
+	for !g.HandIsOver() && g.Turn != e.Seat {
+		agent := e.Opponents[(g.Turn-e.Seat-1+len(g.Players))%len(g.Players)]
+		a := agent.Act(g.ViewFor(g.Players[g.Turn]), g.LegalActions())
+		if err := g.Apply(a); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func boolToFloat(b bool) float64 {
+	if b {
+		return 1
+	}
+
+	return 0
+}
@@ -0,0 +1,154 @@
+package poker_test
+
+import (
+	"testing"
+
+	"github.com/arturo-source/poker-engine"
+)
+
+func TestEncodeCards(t *testing.T) {
+	c := poker.NewCard
+	encoded := (c("2c") | c("As")).Encode()
+
+	if len(encoded) != poker.MAX_CARDS {
+		t.Fatalf("\nWant %d\nGot  %d", poker.MAX_CARDS, len(encoded))
+	}
+
+	var sum float64
+	for _, v := range encoded {
+		sum += v
+	}
+	if sum != 2 || encoded[0] != 1 || encoded[poker.MAX_CARDS-1] != 1 {
+		t.Errorf("Wrong encoding: %v", encoded)
+	}
+}
+
+func TestEnvResetIsReproducible(t *testing.T) {
+	env := poker.NewEnv([]poker.Agent{callingAgent{}}, 100, 1, 2)
+
+	obs1, _, _, err := env.Reset(42)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	obs2, _, _, _ := env.Reset(42)
+
+	if len(obs1) != env.ObservationSize() {
+		t.Fatalf("\nWant %d\nGot  %d", env.ObservationSize(), len(obs1))
+	}
+	for i := range obs1 {
+		if obs1[i] != obs2[i] {
+			t.Fatalf("Observations are different in position %d", i)
+		}
+	}
+}
+
+func TestEnvFoldLosesTheBlind(t *testing.T) {
+	env := poker.NewEnv([]poker.Agent{callingAgent{}}, 100, 1, 2)
+
+	// Look for a hand where the learning agent is the small blind and acts first
+	var seed int64
+	for ; ; seed++ {
+		env.Reset(seed)
+		if env.Game.Button == env.Seat {
+			break
+		}
+	}
+
+	mask := env.ActionMask()
+	if !mask[poker.ENV_FOLD] || !mask[poker.ENV_CHECK_CALL] || !mask[env.NumActions()-1] {
+		t.Errorf("Wrong action mask: %v", mask)
+	}
+
+	_, reward, done, err := env.Step(poker.ENV_FOLD)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if !done {
+		t.Errorf("Hand should be over")
+	}
+	if reward != -1 {
+		t.Errorf("\nWant %d\nGot  %f", -1, reward)
+	}
+
+	_, _, _, err = env.Step(poker.ENV_CHECK_CALL)
+	if err == nil {
+		t.Errorf("Wanted an error. Got nil.")
+	}
+}
+
+func TestEnvPlaysUntilTheEnd(t *testing.T) {
+	env := poker.NewEnv([]poker.Agent{callingAgent{}, callingAgent{}}, 100, 1, 2)
+
+	for seed := int64(0); seed < 20; seed++ {
+		_, total, _, err := env.Reset(seed)
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+
+		for !env.Done() {
+			// bet pot every time it's possible, or call
+			action := poker.ENV_CHECK_CALL
+			if env.ActionMask()[poker.ENV_FIRST_BET_SIZE+1] {
+				action = poker.ENV_FIRST_BET_SIZE + 1
+			}
+
+			_, reward, _, err := env.Step(action)
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			total += reward
+		}
+
+		var coins uint
+		for _, p := range env.Game.Players {
+			coins += p.Coins
+		}
+		if coins != 300 {
+			t.Errorf("Coins are not conserved: %d", coins)
+		}
+		if want := float64(env.Game.Players[env.Seat].Coins) - 100; total != want {
+			t.Errorf("\nWant %f\nGot  %f", want, total)
+		}
+	}
+}
+
+// foldingAgent always checks or folds.
+type foldingAgent struct{}
+
+func (foldingAgent) Act(view poker.GameView, legalActions []poker.Action) poker.Action {
+	return legalActions[0]
+}
+
+func TestEnvHandOverInReset(t *testing.T) {
+	env := poker.NewEnv([]poker.Agent{foldingAgent{}}, 100, 1, 2)
+
+	// Look for a hand where the opponent is the small blind, so it folds before the learning agent acts
+	var seed int64
+	for ; ; seed++ {
+		_, reward, done, err := env.Reset(seed)
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if env.Game.Button == env.Seat {
+			if done || reward != 0 {
+				t.Fatalf("The learning agent has to act first, but done is %v and reward %f", done, reward)
+			}
+			continue
+		}
+
+		if !done {
+			t.Fatalf("Hand should be over")
+		}
+		if reward != 1 {
+			t.Errorf("\nWant %d\nGot  %f", 1, reward)
+		}
+		break
+	}
+
+	if mask := env.ActionMask(); mask[poker.ENV_FOLD] || mask[poker.ENV_CHECK_CALL] {
+		t.Errorf("No action should be legal: %v", mask)
+	}
+	if _, _, _, err := env.Step(poker.ENV_CHECK_CALL); err == nil {
+		t.Errorf("Wanted an error. Got nil.")
+	}
+}
@@ -0,0 +1,89 @@
+package poker
+
+import "math/bits"
+
+// handValue returns a number for the best hand of five of the cards (usually seven, the hand and the table cards),
+// which is higher for better hands, and the same for hands which tie. It is the same as comparing with BestHand
+// and the tie breakers, but much faster, which is needed when the hands of every board are compared.
+//
+// The value is the HandKind, then a mask with the ranks which decide first (the pair, the trips of a full house, etc.),
+// and then a mask with the kickers.
+func handValue(cards Cards) uint32 {
+	var suits [4]uint32
+	for i := range suits {
+		suits[i] = uint32(cards>>(13*i)) & 0x1FFF
+	}
+	ranks := suits[0] | suits[1] | suits[2] | suits[3]
+
+	for _, suit := range suits {
+		if bits.OnesCount32(suit) < 5 {
+			continue
+		}
+
+		if top := straightTop(suit); top != 0 {
+			if top == 1<<12 {
+				return rankValue(ROYALFLUSH, top, 0)
+			}
+			return rankValue(STRAIGHTFLUSH, top, 0)
+		}
+		// With 7 cards there can't be a flush and quads or a full house
+		return rankValue(FLUSH, topRanks(suit, 5), 0)
+	}
+
+	four := suits[0] & suits[1] & suits[2] & suits[3]
+	if four != 0 {
+		return rankValue(FOUROFAKIND, four, topRanks(ranks&^four, 1))
+	}
+
+	atLeastTwo := suits[0]&suits[1] | suits[0]&suits[2] | suits[0]&suits[3] | suits[1]&suits[2] | suits[1]&suits[3] | suits[2]&suits[3]
+	three := (suits[0]&suits[1]&(suits[2]|suits[3]) | suits[2]&suits[3]&(suits[0]|suits[1]))
+	pairs := atLeastTwo &^ three
+
+	if three != 0 {
+		trips := topRanks(three, 1)
+		if pair := topRanks(three&^trips|pairs, 1); pair != 0 {
+			return rankValue(FULLHOUSE, trips, pair)
+		}
+	}
+
+	if top := straightTop(ranks); top != 0 {
+		return rankValue(STRAIGHT, top, 0)
+	}
+
+	switch {
+	case three != 0:
+		return rankValue(THREEOFAKIND, three, topRanks(ranks&^three, 2))
+	case bits.OnesCount32(pairs) >= 2:
+		twoPair := topRanks(pairs, 2)
+		return rankValue(TWOPAIR, twoPair, topRanks(ranks&^twoPair, 1))
+	case pairs != 0:
+		return rankValue(PAIR, pairs, topRanks(ranks&^pairs, 3))
+	default:
+		return rankValue(HIGHCARD, topRanks(ranks, 5), 0)
+	}
+}
+
+func rankValue(kind HandKind, first, kickers uint32) uint32 {
+	return uint32(kind)<<26 | first<<13 | kickers
+}
+
+// straightTop returns the highest card of the highest straight of the ranks (the five in the wheel), or 0 if there is none.
+func straightTop(ranks uint32) uint32 {
+	// the ace is also below the two
+	r := ranks<<1 | (ranks>>12)&1
+	straights := r & (r >> 1) & (r >> 2) & (r >> 3) & (r >> 4)
+	if straights == 0 {
+		return 0
+	}
+
+	return 1 << (bits.Len32(straights) + 2)
+}
+
+// topRanks returns the n highest ranks of the mask.
+func topRanks(ranks uint32, n int) uint32 {
+	for bits.OnesCount32(ranks) > n {
+		ranks &= ranks - 1
+	}
+
+	return ranks
+}
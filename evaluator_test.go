@@ -0,0 +1,54 @@
+package poker
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestHandValueAgreesWithBestHand(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	deck := ALL_CARDS.Split()
+
+	for n := 0; n < 50000; n++ {
+		r.Shuffle(len(deck), func(i, j int) { deck[i], deck[j] = deck[j], deck[i] })
+		hand, opponent := deck[0]|deck[1], deck[2]|deck[3]
+		board := JoinCards(deck[4:9]...)
+
+		want := compareHands(hand, opponent, board)
+		got := tied
+		if a, b := handValue(hand|board), handValue(opponent|board); a > b {
+			got = ahead
+		} else if a < b {
+			got = behind
+		}
+
+		if want != got {
+			t.Fatalf("%s vs %s on %s\nWant %d\nGot  %d", hand, opponent, board, want, got)
+		}
+	}
+}
+
+func TestHandValueKinds(t *testing.T) {
+	c := NewCard
+	tests := []struct {
+		cards Cards
+		want  HandKind
+	}{
+		{c("As") | c("Ks") | c("Qs") | c("Js") | c("Ts") | c("2d") | c("2c"), ROYALFLUSH},
+		{c("As") | c("2s") | c("3s") | c("4s") | c("5s") | c("Kd") | c("Kc"), STRAIGHTFLUSH},
+		{c("7s") | c("7d") | c("7h") | c("7c") | c("5s") | c("5d") | c("5c"), FOUROFAKIND},
+		{c("7s") | c("7d") | c("7h") | c("5c") | c("5s") | c("5d") | c("2c"), FULLHOUSE},
+		{c("As") | c("9s") | c("3s") | c("4s") | c("5s") | c("6d") | c("7c"), FLUSH},
+		{c("Ad") | c("2s") | c("3s") | c("4s") | c("5h") | c("Kd") | c("Kc"), STRAIGHT},
+		{c("7s") | c("7d") | c("7h") | c("5c") | c("4s") | c("Qd") | c("2c"), THREEOFAKIND},
+		{c("7s") | c("7d") | c("5h") | c("5c") | c("4s") | c("4d") | c("2c"), TWOPAIR},
+		{c("7s") | c("7d") | c("5h") | c("Ac") | c("4s") | c("Qd") | c("2c"), PAIR},
+		{c("7s") | c("8d") | c("5h") | c("Ac") | c("4s") | c("Qd") | c("2c"), HIGHCARD},
+	}
+
+	for _, tt := range tests {
+		if got := HandKind(handValue(tt.cards) >> 26); tt.want != got {
+			t.Errorf("%s\nWant %s\nGot  %s", tt.cards, tt.want, got)
+		}
+	}
+}
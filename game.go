@@ -25,29 +25,92 @@ func play() {
 	}
 }
 
-// Game represents a game state which has: many players, a board, and a deck.
+// Game represents a game state which has: many players, a board, a deck,
+// the coins collected in the pot, and the history of actions done in the hand.
+//
+// Button is the seat of the dealer, and Turn the seat of the player who has to act (NO_TURN if nobody has to).
+// Structure decides the bets and raises allowed, if it is nil the game is NoLimit.
+// If RunItTimes is more than 1, the board is dealt that many times when the players are all-in with table cards
+// still to deal, every pot is split between the runouts, and Runouts has the boards and who won in each one.
+// OddChipRule decides who gets the odd coins of a split pot, and Payouts has what each player won from each pot
+// when the hand is over.
 type Game struct {
-	Players []*Player
-	Board   *Board
-	Deck    *Deck
+	Players     []*Player
+	Board       *Board
+	Deck        *Deck
+	Pot         uint
+	History     []Action
+	Button      int
+	Turn        int
+	SmallBlind  uint
+	BigBlind    uint
+	Ante        uint
+	Structure   BettingStructure
+	RunItTimes  int
+	Runouts     []Runout
+	OddChipRule OddChipRule
+	Payouts     []Payout
+
+	lastRaise uint
+	raises    int
+	handOver  bool
 }
 
 // NewGame is an easy way to init a Game with default values.
 func NewGame() *Game {
-	d := NewDeck()
-	b := NewBoard(d)
+	return NewVariantGame(HOLDEM)
+}
+
+// NewVariantGame inits a Game with default values, which is played with the cards and rules of the variant.
+func NewVariantGame(v *Variant) *Game {
+	d := NewDeckFrom(v.Deck)
+	b := NewVariantBoard(d, v)
 
 	return &Game{
 		Players: make([]*Player, 0),
 		Board:   b,
 		Deck:    d,
+		History: make([]Action, 0),
+		Turn:    NO_TURN,
 	}
 }
 
-// DealCards deals one card per each player, and deals another one for each one again.
+// Clone returns a deep copy of the game (players, board and deck included),
+// so the copy can be played without changing the original one.
+func (g *Game) Clone() *Game {
+	clone := *g
+
+	deck := *g.Deck
+	deck.cards = append([]Cards(nil), g.Deck.cards...)
+	clone.Deck = &deck
+
+	board := *g.Board
+	board.deck = &deck
+	board.TableCards = append(make([]Cards, 0, MAX_CARDS_IN_BOARD), g.Board.TableCards...)
+	board.BurnedCards = append(make([]Cards, 0, MAX_BURNED_CARDS), g.Board.BurnedCards...)
+	clone.Board = &board
+
+	clone.Players = make([]*Player, len(g.Players))
+	for i, p := range g.Players {
+		player := *p
+		clone.Players[i] = &player
+	}
+	clone.History = append([]Action(nil), g.History...)
+	clone.Runouts = append([]Runout(nil), g.Runouts...)
+	clone.Payouts = append([]Payout(nil), g.Payouts...)
+
+	return &clone
+}
+
+// DealCards deals one card per each player, and deals another one for each one again (as many cards as the variant has per hand).
+// Players who have folded (sitting out the hand) don't receive cards.
 func (g *Game) DealCards() error {
-	for i := 0; i < MAX_CARDS_PER_HAND; i++ {
+	for i := 0; i < g.Board.variant().HandCards; i++ {
 		for j := range g.Players {
+			if g.Players[j].HasFolded {
+				continue
+			}
+
 			card := g.Deck.GetNextCard()
 			if card == NO_CARD {
 				return errNoCardsInDeck
@@ -131,27 +194,9 @@ func BestHand(p *Player, tableCards Cards) (Cards, HandKind) {
 	return NO_CARD, -1
 }
 
-// tieBreakerHighCard returns the player which has the highest card.
+// tieBreakerHighCard follows commonTieBreaker logic, so the kickers decide when the highest cards are the same.
 func tieBreakerHighCard(p1, p2 *Player, p1WinningCards, p2WinningCards Cards, tableCards Cards) *Player {
-	p1WCOnesuited := p1WinningCards.mergeSuits()
-	p2WCOnesuited := p2WinningCards.mergeSuits()
-
-	for n := ACES; n >= TWOS; n >>= 1 {
-		p1Cards := p1WCOnesuited & n
-		p2Cards := p2WCOnesuited & n
-
-		p1CardsCount := p1Cards.Count()
-		p2CardsCount := p2Cards.Count()
-
-		if p1CardsCount > p2CardsCount {
-			return p1
-		}
-		if p2CardsCount > p1CardsCount {
-			return p2
-		}
-	}
-
-	return nil
+	return commonTieBreaker(p1, p2, p1WinningCards, p2WinningCards, tableCards)
 }
 
 // commonTieBreaker returns the player with best hand in the common cases.
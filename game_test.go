@@ -66,6 +66,31 @@ func TestHighCardWins(t *testing.T) {
 	}
 }
 
+func TestHighCardKickerWins(t *testing.T) {
+	g := poker.NewGame()
+	p1 := poker.NewPlayer("P1")
+	p2 := poker.NewPlayer("P2")
+	g.Players = []*poker.Player{p1, p2}
+
+	c := poker.NewCard
+	p1.Hand = c("Ts") | c("2s")
+	p2.Hand = c("8d") | c("3s")
+
+	g.Board.TableCards = []poker.Cards{c("Ac"), c("Jc"), c("9h"), c("7h"), c("6c")}
+	tableCards := poker.JoinCards(g.Board.TableCards...)
+
+	winners := poker.GetWinners(tableCards, g.Players)
+	if len(winners) != 1 {
+		t.Fatalf("Expected 1 winner, got %d winners", len(winners))
+	}
+
+	want := p1
+	got := winners[0]
+	if want != got.Player {
+		t.Errorf("\nWant %v\nGot  %v", want, got)
+	}
+}
+
 func TestPairTie(t *testing.T) {
 	g := poker.NewGame()
 	p1 := poker.NewPlayer("P1")
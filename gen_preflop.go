@@ -0,0 +1,24 @@
+//go:build ignore
+
+// gen_preflop writes preflop_equities.gz, the table of preflop equities embedded in the package.
+// Run it with go generate (it takes more than an hour).
+package main
+
+import (
+	"log"
+	"os"
+
+	"github.com/arturo-source/poker-engine"
+)
+
+func main() {
+	f, err := os.Create("preflop_equities.gz")
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer f.Close()
+
+	if err := poker.WritePreflopEquities(f); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,245 @@
+package poker
+
+import (
+	"errors"
+	"fmt"
+	"html"
+	"math"
+	"sort"
+	"strings"
+)
+
+var errWrongHandClass = errors.New("a hand class is written as a pair (\"77\"), or two ranks suited or offsuit (\"AKs\", \"T9o\")")
+
+// HAND_CLASSES is the number of classes of starting hands: 13 pairs, and 78 suited and 78 offsuit hands.
+const HAND_CLASSES = 169
+
+// gridRanks are the ranks of the rows and columns of the grid, from the aces.
+const gridRanks = "AKQJT98765432"
+
+// HandClass is a class of starting hands, which are played the same way before the flop because only their suits change:
+// a pair ("77"), or two ranks suited ("AKs") or offsuit ("T9o").
+// It is the cell of the class in the 13x13 grid (row*13 + column), with the aces in the first row and column,
+// the pairs in the diagonal, the suited hands above it (the row is the highest rank)
+// and the offsuit hands below it (the column is the highest rank).
+type HandClass int
+
+// NewHandClass returns the class of a hand of two cards.
+// Returns an error if the hand doesn't have two cards.
+func NewHandClass(hand Cards) (HandClass, error) {
+	if hand.Count() != 2 {
+		return 0, errWrongHandClass
+	}
+
+	return handClass(hand), nil
+}
+
+// handClass returns the class of a hand of two cards.
+func handClass(hand Cards) HandClass {
+	cards := hand.Split()
+	high, low := 12-cardRank(cards[0]), 12-cardRank(cards[1])
+	if cards[0]&suitOf(cards[1]) != NO_CARD {
+		return HandClassAt(high, low)
+	}
+
+	return HandClassAt(low, high)
+}
+
+// ParseHandClass returns the class written in the usual notation: "77", "AKs" or "T9o".
+// Neither the case nor the order of the ranks matter, so "aks", "AKS" and "KAs" are also "AKs".
+// Returns an error if it isn't a class (a hand without pair needs the suffix, "AK" is two classes).
+func ParseHandClass(s string) (HandClass, error) {
+	if len(s) < 2 || len(s) > 3 {
+		return 0, errWrongHandClass
+	}
+
+	s = strings.ToUpper(s)
+	high := strings.IndexByte(gridRanks, s[0])
+	low := strings.IndexByte(gridRanks, s[1])
+	if high < 0 || low < 0 {
+		return 0, errWrongHandClass
+	}
+	if high > low {
+		high, low = low, high
+	}
+
+	switch {
+	case len(s) == 2 && high == low:
+		return HandClassAt(high, low), nil
+	case len(s) == 3 && high != low && s[2] == 'S':
+		return HandClassAt(high, low), nil
+	case len(s) == 3 && high != low && s[2] == 'O':
+		return HandClassAt(low, high), nil
+	}
+
+	return 0, errWrongHandClass
+}
+
+// HandClassAt returns the class in that row and column of the grid (from 0 to 12).
+func HandClassAt(row, col int) HandClass {
+	return HandClass(row*13 + col)
+}
+
+// Grid returns the row and the column of the class in the grid.
+func (hc HandClass) Grid() (row, col int) {
+	return int(hc) / 13, int(hc) % 13
+}
+
+// IsPair returns true if both cards have the same rank.
+func (hc HandClass) IsPair() bool {
+	row, col := hc.Grid()
+	return row == col
+}
+
+// IsSuited returns true if both cards have the same suit.
+func (hc HandClass) IsSuited() bool {
+	row, col := hc.Grid()
+	return row < col
+}
+
+// String returns the class in the usual notation: "77", "AKs" or "T9o".
+func (hc HandClass) String() string {
+	if hc < 0 || hc >= HAND_CLASSES {
+		return "Unknown HandClass"
+	}
+
+	row, col := hc.Grid()
+	switch {
+	case row == col:
+		return gridRanks[row:row+1] + gridRanks[col:col+1]
+	case row < col:
+		return gridRanks[row:row+1] + gridRanks[col:col+1] + "s"
+	default:
+		return gridRanks[col:col+1] + gridRanks[row:row+1] + "o"
+	}
+}
+
+// Hands returns every hand of two cards of the class, sorted.
+func (hc HandClass) Hands() []Cards {
+	row, col := hc.Grid()
+	high, low := 12-row, 12-col
+	if row > col {
+		high, low = low, high
+	}
+
+	hands := make([]Cards, 0, 12)
+	for s1 := 0; s1 < 4; s1++ {
+		for s2 := 0; s2 < 4; s2++ {
+			hand := NO_CARD.SetBit(s1*13+high) | NO_CARD.SetBit(s2*13+low)
+			switch {
+			case hc.IsPair() && s1 >= s2, hc.IsSuited() && s1 != s2, !hc.IsPair() && !hc.IsSuited() && s1 == s2:
+				continue
+			}
+			hands = append(hands, hand)
+		}
+	}
+	sort.Slice(hands, func(i, j int) bool { return hands[i] < hands[j] })
+
+	return hands
+}
+
+// Combos returns the number of hands of the class: 6 for pairs, 4 for suited hands and 12 for offsuit hands.
+func (hc HandClass) Combos() int {
+	switch {
+	case hc.IsPair():
+		return 6
+	case hc.IsSuited():
+		return 4
+	default:
+		return 12
+	}
+}
+
+// suitOf returns every card of the suit of the card.
+func suitOf(card Cards) Cards {
+	for suit := FIRST_SUIT; suit < ALL_CARDS; suit <<= 13 {
+		if card&suit != NO_CARD {
+			return suit
+		}
+	}
+
+	return NO_CARD
+}
+
+// HandGrid has a value for each HandClass, such as how often the class is played or its equity, from 0 to 1.
+type HandGrid [HAND_CLASSES]float64
+
+// RangeGrid returns the average weight of the hands of each class in the range (nil is every hand with weight 1).
+func RangeGrid(r Range) HandGrid {
+	if r == nil {
+		r = FullRange()
+	}
+
+	var grid HandGrid
+	for hand, weight := range r {
+		if hand.Count() == 2 {
+			grid[handClass(hand)] += weight
+		}
+	}
+	for hc := range grid {
+		grid[hc] /= float64(HandClass(hc).Combos())
+	}
+
+	return grid
+}
+
+// Range returns every hand of the classes with some value, with the value as weight.
+func (g HandGrid) Range() Range {
+	r := make(Range)
+	for hc, value := range g {
+		if value <= 0 {
+			continue
+		}
+		for _, hand := range HandClass(hc).Hands() {
+			r[hand] = value
+		}
+	}
+
+	return r
+}
+
+// String returns the grid with the values as percentages, and the ranks of the rows and columns.
+func (g HandGrid) String() string {
+	var sb strings.Builder
+	sb.WriteString("  ")
+	for _, rank := range gridRanks {
+		fmt.Fprintf(&sb, " %4c", rank)
+	}
+	sb.WriteString("\n")
+
+	for row := 0; row < 13; row++ {
+		fmt.Fprintf(&sb, "%c ", gridRanks[row])
+		for col := 0; col < 13; col++ {
+			fmt.Fprintf(&sb, " %4.0f", math.Round(g[HandClassAt(row, col)]*100))
+		}
+		sb.WriteString("\n")
+	}
+
+	return sb.String()
+}
+
+// SVG returns the grid as an SVG image with a square of cellSize pixels for each class, with its name and its value
+// as a percentage. The squares are colored from white (0) to the color (1), which is a CSS color such as "#2e7d32".
+func (g HandGrid) SVG(cellSize int, color string) string {
+	var sb strings.Builder
+	size := 13 * cellSize
+	fmt.Fprintf(&sb, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" font-family="sans-serif">`+"\n",
+		size, size, size, size)
+	fmt.Fprintf(&sb, `<rect width="%d" height="%d" fill="white"/>`+"\n", size, size)
+
+	for hc, value := range g {
+		row, col := HandClass(hc).Grid()
+		x, y := col*cellSize, row*cellSize
+		opacity := math.Max(0, math.Min(1, value))
+
+		fmt.Fprintf(&sb, `<rect x="%d" y="%d" width="%d" height="%d" fill="%s" fill-opacity="%.2f" stroke="#999"/>`+"\n",
+			x, y, cellSize, cellSize, html.EscapeString(color), opacity)
+		fmt.Fprintf(&sb, `<text x="%d" y="%d" font-size="%d" text-anchor="middle">%s</text>`+"\n",
+			x+cellSize/2, y+cellSize*2/5, cellSize/4, HandClass(hc))
+		fmt.Fprintf(&sb, `<text x="%d" y="%d" font-size="%d" text-anchor="middle">%.0f%%</text>`+"\n",
+			x+cellSize/2, y+cellSize*4/5, cellSize/5, math.Round(value*100))
+	}
+	sb.WriteString("</svg>\n")
+
+	return sb.String()
+}
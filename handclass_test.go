@@ -0,0 +1,169 @@
+package poker_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/arturo-source/poker-engine"
+)
+
+func TestHandClassNotation(t *testing.T) {
+	seen := make(map[string]bool)
+	for hc := poker.HandClass(0); hc < poker.HAND_CLASSES; hc++ {
+		s := hc.String()
+		if seen[s] {
+			t.Errorf("%s is the name of two classes", s)
+		}
+		seen[s] = true
+
+		parsed, err := poker.ParseHandClass(s)
+		if err != nil {
+			t.Fatalf("Unexpected error parsing %s: %s", s, err)
+		}
+		if parsed != hc {
+			t.Errorf("%s should be parsed as %d, but it is %d", s, hc, parsed)
+		}
+	}
+
+	for _, s := range []string{"", "A", "AA7", "AAs", "AKx", "AK", "kA", "1Ks", "AKso", "aas", "akx", "9T"} {
+		if _, err := poker.ParseHandClass(s); err == nil {
+			t.Errorf("%q shouldn't be a hand class", s)
+		}
+	}
+}
+
+func TestParseHandClassIgnoresCaseAndOrder(t *testing.T) {
+	tests := []struct {
+		s, want string
+	}{
+		{"AKS", "AKs"},
+		{"aks", "AKs"},
+		{"T9O", "T9o"},
+		{"t9o", "T9o"},
+		{"qJs", "QJs"},
+		{"tt", "TT"},
+		{"KAs", "AKs"},
+		{"kAs", "AKs"},
+		{"9To", "T9o"},
+		{"9tO", "T9o"},
+		{"2As", "A2s"},
+	}
+
+	for _, tt := range tests {
+		hc, err := poker.ParseHandClass(tt.s)
+		if err != nil {
+			t.Fatalf("Unexpected error parsing %q: %s", tt.s, err)
+		}
+		if hc.String() != tt.want {
+			t.Errorf("%q\nWant %s\nGot  %s", tt.s, tt.want, hc)
+		}
+	}
+}
+
+func TestHandClassOfHands(t *testing.T) {
+	c := poker.NewCard
+	tests := []struct {
+		hand     poker.Cards
+		class    string
+		row, col int
+	}{
+		{c("Ah") | c("Kh"), "AKs", 0, 1},
+		{c("Kd") | c("Ac"), "AKo", 1, 0},
+		{c("7s") | c("7c"), "77", 7, 7},
+		{c("Ts") | c("9d"), "T9o", 5, 4},
+		{c("3c") | c("2c"), "32s", 11, 12},
+	}
+
+	for _, tt := range tests {
+		hc, err := poker.NewHandClass(tt.hand)
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if hc.String() != tt.class {
+			t.Errorf("%s should be %s, but it is %s", tt.hand, tt.class, hc)
+		}
+		if row, col := hc.Grid(); row != tt.row || col != tt.col {
+			t.Errorf("%s should be in (%d, %d), but it is in (%d, %d)", tt.class, tt.row, tt.col, row, col)
+		}
+		if poker.HandClassAt(tt.row, tt.col) != hc {
+			t.Errorf("%s should be at (%d, %d)", tt.class, tt.row, tt.col)
+		}
+	}
+
+	if _, err := poker.NewHandClass(c("Ah")); err == nil {
+		t.Errorf("A hand of one card shouldn't have a class")
+	}
+}
+
+func TestHandClassCombos(t *testing.T) {
+	all := make(map[poker.Cards]bool)
+	var combos int
+	for hc := poker.HandClass(0); hc < poker.HAND_CLASSES; hc++ {
+		hands := hc.Hands()
+		if len(hands) != hc.Combos() {
+			t.Errorf("%s should have %d hands, but it has %d", hc, hc.Combos(), len(hands))
+		}
+		for _, hand := range hands {
+			if class, _ := poker.NewHandClass(hand); class != hc {
+				t.Errorf("%s is %s, not %s", hand, class, hc)
+			}
+			all[hand] = true
+		}
+		combos += hc.Combos()
+	}
+
+	if combos != 1326 || len(all) != 1326 {
+		t.Errorf("The classes should have the 1326 hands, but they have %d (%d different)", combos, len(all))
+	}
+
+	tests := map[string]int{"AA": 6, "AKs": 4, "AKo": 12}
+	for s, expected := range tests {
+		hc, _ := poker.ParseHandClass(s)
+		if hc.Combos() != expected || hc.IsPair() != (s == "AA") || hc.IsSuited() != (s == "AKs") {
+			t.Errorf("%s should have %d combos", s, expected)
+		}
+	}
+}
+
+func TestHandGrid(t *testing.T) {
+	c := poker.NewCard
+	full := poker.RangeGrid(poker.FullRange())
+	for hc, value := range full {
+		if value != 1 {
+			t.Errorf("Every class of the full range should have weight 1, but %s has %f", poker.HandClass(hc), value)
+		}
+	}
+
+	r := poker.Range{c("Ah") | c("Kh"): 1, c("As") | c("Ks"): 1, c("7s") | c("7c"): 0.75}
+	grid := poker.RangeGrid(r)
+	aks, _ := poker.ParseHandClass("AKs")
+	sevens, _ := poker.ParseHandClass("77")
+	if grid[aks] != 0.5 || grid[sevens] != 0.125 {
+		t.Errorf("AKs should have 0.5 and 77 0.125, but they have %f and %f", grid[aks], grid[sevens])
+	}
+
+	back := grid.Range()
+	if len(back) != 4+6 || back[c("Ad")|c("Kd")] != 0.5 {
+		t.Errorf("Every hand of the classes should be in the range with the value of its class: %v", back)
+	}
+
+	text := grid.String()
+	lines := strings.Split(strings.TrimSpace(text), "\n")
+	if len(lines) != 14 || !strings.HasPrefix(lines[1], "A ") {
+		t.Errorf("The grid should have a header and 13 rows:\n%s", text)
+	}
+	if !strings.Contains(lines[1], "  50") {
+		t.Errorf("AKs should be shown as 50%%:\n%s", text)
+	}
+
+	svg := grid.SVG(40, "#2e7d32")
+	if !strings.HasPrefix(svg, "<svg") || !strings.HasSuffix(svg, "</svg>\n") {
+		t.Errorf("It should be an SVG image:\n%s", svg)
+	}
+	if n := strings.Count(svg, "<rect"); n != poker.HAND_CLASSES+1 {
+		t.Errorf("There should be a square for each class, and the background, but there are %d", n)
+	}
+	if !strings.Contains(svg, ">AKs<") || !strings.Contains(svg, `fill-opacity="0.50"`) {
+		t.Errorf("AKs should be in the image, colored by its value")
+	}
+}
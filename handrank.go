@@ -0,0 +1,116 @@
+package poker
+
+import "sort"
+
+// RankedHand is a hand of two cards ranked against every other hand possible on a table.
+// Rank is 1 for the nuts, 2 for the second nuts, etc., and hands which tie have the same Rank.
+type RankedHand struct {
+	Hand     Cards
+	BestHand Cards
+	HandKind HandKind
+	Rank     int
+}
+
+// HandRanking is every hand of two cards possible on a table, sorted from the best to the worst.
+type HandRanking struct {
+	TableCards Cards
+	Hands      []RankedHand
+}
+
+// RankHands evaluates every hand of two cards which doesn't use the table cards, and ranks them.
+func RankHands(tableCards []Cards) HandRanking {
+	board := JoinCards(tableCards...)
+	cards := ALL_CARDS.QuitCards(board).Split()
+
+	values := make([]PlayerHandValue, 0, len(cards)*(len(cards)-1)/2)
+	for i := range cards {
+		for j := i + 1; j < len(cards); j++ {
+			p := &Player{Hand: cards[i] | cards[j]}
+			bestHand, handKind := BestHand(p, board)
+			values = append(values, PlayerHandValue{p, bestHand, handKind})
+		}
+	}
+
+	sort.SliceStable(values, func(i, j int) bool {
+		return compareHandValues(values[i], values[j], board) == values[i].Player
+	})
+
+	ranking := HandRanking{TableCards: board, Hands: make([]RankedHand, 0, len(values))}
+	rank := 0
+	for i, v := range values {
+		if i == 0 || compareHandValues(values[i-1], v, board) != nil {
+			rank++
+		}
+		ranking.Hands = append(ranking.Hands, RankedHand{v.Player.Hand, v.BestHand, v.HandKind, rank})
+	}
+
+	return ranking
+}
+
+// Nuts returns the best hands on the table (more than one if they tie).
+func (hr HandRanking) Nuts() []RankedHand {
+	nuts := make([]RankedHand, 0)
+	for _, rh := range hr.Hands {
+		if rh.Rank != 1 {
+			break
+		}
+		nuts = append(nuts, rh)
+	}
+
+	return nuts
+}
+
+// Find returns the hand ranked, and false if it isn't possible on the table.
+func (hr HandRanking) Find(hand Cards) (RankedHand, bool) {
+	for _, rh := range hr.Hands {
+		if rh.Hand == hand {
+			return rh, true
+		}
+	}
+
+	return RankedHand{}, false
+}
+
+// Beats returns the fraction (from 0 to 1) of the hands which the hand beats, counting ties as half.
+// Only the hands which don't share cards with it are counted.
+// Returns 0 if the hand isn't possible on the table.
+func (hr HandRanking) Beats(hand Cards) float64 {
+	ranked, found := hr.Find(hand)
+	if !found {
+		return 0
+	}
+
+	var beaten float64
+	total := 0
+	for _, rh := range hr.Hands {
+		if rh.Hand.CardsArePresent(hand) {
+			continue
+		}
+
+		total++
+		switch {
+		case rh.Rank > ranked.Rank:
+			beaten++
+		case rh.Rank == ranked.Rank:
+			beaten += 0.5
+		}
+	}
+
+	if total == 0 {
+		return 0
+	}
+
+	return beaten / float64(total)
+}
+
+// compareHandValues returns the player with the best hand, or nil if they tie.
+func compareHandValues(a, b PlayerHandValue, tableCards Cards) *Player {
+	switch {
+	case a.HandKind > b.HandKind:
+		return a.Player
+	case b.HandKind > a.HandKind:
+		return b.Player
+	}
+
+	return tieBreakerFuncs[a.HandKind](a.Player, b.Player, a.BestHand, b.BestHand, tableCards)
+}
@@ -0,0 +1,57 @@
+package poker_test
+
+import (
+	"testing"
+
+	"github.com/arturo-source/poker-engine"
+)
+
+func TestRankHandsNuts(t *testing.T) {
+	c := poker.NewCard
+	ranking := poker.RankHands([]poker.Cards{c("Ah"), c("Kh"), c("Qh"), c("7c"), c("2d")})
+
+	if len(ranking.Hands) != 1081 {
+		t.Fatalf("\nWant %d\nGot  %d", 1081, len(ranking.Hands))
+	}
+
+	nuts := ranking.Nuts()
+	if len(nuts) != 1 || nuts[0].Hand != c("Jh")|c("Th") || nuts[0].HandKind != poker.ROYALFLUSH {
+		t.Errorf("Wrong nuts: %v", nuts)
+	}
+
+	second, found := ranking.Find(c("Jh") | c("9h"))
+	if !found || second.Rank != 2 {
+		t.Errorf("Jh9h should be the second nuts: %+v", second)
+	}
+
+	if beats := ranking.Beats(c("Jh") | c("Th")); beats != 1 {
+		t.Errorf("\nWant %f\nGot  %f", 1.0, beats)
+	}
+}
+
+func TestRankHandsTies(t *testing.T) {
+	c := poker.NewCard
+	ranking := poker.RankHands([]poker.Cards{c("Ks"), c("Kd"), c("7c"), c("4h"), c("2s")})
+
+	nuts := ranking.Nuts()
+	if len(nuts) != 1 || nuts[0].Hand != c("Kc")|c("Kh") {
+		t.Errorf("Wrong nuts: %v", nuts)
+	}
+
+	// Every A7 (without the 7c) makes the same hand
+	a7, _ := ranking.Find(c("As") | c("7d"))
+	other, _ := ranking.Find(c("Ah") | c("7s"))
+	if a7.Rank != other.Rank {
+		t.Errorf("A7 hands should tie: %d and %d", a7.Rank, other.Rank)
+	}
+
+	for i := 1; i < len(ranking.Hands); i++ {
+		if ranking.Hands[i].Rank < ranking.Hands[i-1].Rank {
+			t.Fatalf("Hands are not sorted")
+		}
+	}
+
+	if _, found := ranking.Find(c("Ks") | c("Ah")); found {
+		t.Errorf("Hands with table cards shouldn't be ranked")
+	}
+}
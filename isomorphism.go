@@ -0,0 +1,346 @@
+package poker
+
+import (
+	"errors"
+	"sort"
+)
+
+var errWrongCardsToIndex = errors.New("wrong number of cards for the hand indexer")
+
+// HandIndexer maps a (hand, board) pair of one BoardState to a dense index, and back.
+// Pairs which are equal except for the suits (AhKh on 2c3c4d, and AsKs on 2d3d4h) are suit isomorphic,
+// and they get the same index, so the indexes go from 0 to Size()-1 without gaps.
+// You should always call NewHandIndexer to build it.
+type HandIndexer struct {
+	boardCards int
+	configs    []suitConfig
+	byCounts   map[[4]suitCount]int
+	size       uint64
+}
+
+// suitCount is how many cards of one suit there are in the hand, and in the board.
+type suitCount struct {
+	hand, board int
+}
+
+// suitGroup are the suits of a suitConfig with the same suitCount, so they can be swapped.
+type suitGroup struct {
+	count    suitCount
+	suits    int
+	suitSize uint64 // how many different ways are to have the cards of one suit
+	size     uint64 // how many different ways are to have the cards of all the suits of the group
+}
+
+// suitConfig is a way of distributing the cards between suits (sorted from more to less cards).
+type suitConfig struct {
+	counts [4]suitCount
+	groups []suitGroup
+	offset uint64
+	size   uint64
+}
+
+// NewHandIndexer creates the indexer for a hand of MAX_CARDS_PER_HAND cards, in the state
+// (PREFLOP without board, FLOP with 3 table cards, TURN with 4, and RIVER or SHOWDOWN with 5).
+func NewHandIndexer(state BoardState) *HandIndexer {
+	boardCards := [...]int{0, 3, 4, 5, 5}[clamp(int(state), int(PREFLOP), int(SHOWDOWN))]
+	hi := &HandIndexer{
+		boardCards: boardCards,
+		byCounts:   make(map[[4]suitCount]int),
+	}
+
+	var counts [4]suitCount
+	var generate func(suit, handLeft, boardLeft int)
+	generate = func(suit, handLeft, boardLeft int) {
+		if suit == len(counts) {
+			if handLeft == 0 && boardLeft == 0 {
+				hi.addConfig(counts)
+			}
+			return
+		}
+
+		for h := handLeft; h >= 0; h-- {
+			for b := boardLeft; b >= 0; b-- {
+				count := suitCount{h, b}
+				if h+b > 13 || (suit > 0 && counts[suit-1].less(count)) {
+					continue
+				}
+
+				counts[suit] = count
+				generate(suit+1, handLeft-h, boardLeft-b)
+			}
+		}
+	}
+	generate(0, MAX_CARDS_PER_HAND, boardCards)
+
+	return hi
+}
+
+// addConfig adds the suit distribution to the indexer, after the ones already added.
+func (hi *HandIndexer) addConfig(counts [4]suitCount) {
+	config := suitConfig{counts: counts, offset: hi.size, size: 1}
+
+	for i := 0; i < len(counts); {
+		group := suitGroup{count: counts[i]}
+		for ; i < len(counts) && counts[i] == group.count; i++ {
+			group.suits++
+		}
+
+		group.suitSize = binomial(13, group.count.hand) * binomial(uint64(13-group.count.hand), group.count.board)
+		group.size = binomial(group.suitSize+uint64(group.suits)-1, group.suits)
+		config.groups = append(config.groups, group)
+		config.size *= group.size
+	}
+
+	hi.byCounts[counts] = len(hi.configs)
+	hi.configs = append(hi.configs, config)
+	hi.size += config.size
+}
+
+// Size returns how many different indexes there are.
+func (hi *HandIndexer) Size() uint64 {
+	return hi.size
+}
+
+// Index returns the index of the (hand, board) pair.
+// Returns an error if the hand or the board have a wrong number of cards, or share cards.
+func (hi *HandIndexer) Index(hand, board Cards) (uint64, error) {
+	if hand.Count() != MAX_CARDS_PER_HAND || board.Count() != hi.boardCards || hand.CardsArePresent(board) {
+		return 0, errWrongCardsToIndex
+	}
+
+	suits := sortedSuits(hand, board)
+	var counts [4]suitCount
+	for i, s := range suits {
+		counts[i] = s.count
+	}
+	config := hi.configs[hi.byCounts[counts]]
+
+	var index uint64
+	suit := 0
+	for _, group := range config.groups {
+		values := make([]uint64, group.suits)
+		for i := range values {
+			values[i] = suits[suit].index
+			suit++
+		}
+
+		index = index*group.size + multisetIndex(values)
+	}
+
+	return config.offset + index, nil
+}
+
+// Unindex returns the canonical (hand, board) pair of the index.
+// Returns an error if the index is not lower than Size().
+func (hi *HandIndexer) Unindex(index uint64) (hand, board Cards, err error) {
+	if index >= hi.size {
+		return NO_CARD, NO_CARD, errWrongCardsToIndex
+	}
+
+	c := sort.Search(len(hi.configs), func(i int) bool {
+		return hi.configs[i].offset+hi.configs[i].size > index
+	})
+	config := hi.configs[c]
+	index -= config.offset
+
+	// groups are decoded from the last one, because the first one is the most significant
+	suit := len(config.counts)
+	for g := len(config.groups) - 1; g >= 0; g-- {
+		group := config.groups[g]
+		values := multisetUnindex(index%group.size, group.suits)
+		index /= group.size
+
+		for i := len(values) - 1; i >= 0; i-- {
+			suit--
+			suitHand, suitBoard := suitUnindex(values[i], group.count)
+			hand |= suitHand << (13 * suit)
+			board |= suitBoard << (13 * suit)
+		}
+	}
+
+	return hand, board, nil
+}
+
+// Canonicalize returns the representative of all the (hand, board) pairs which are suit isomorphic to it,
+// which is the same for all of them. The board must have 0, 3, 4 or 5 cards.
+func Canonicalize(hand, board Cards) (Cards, Cards) {
+	suits := sortedSuits(hand, board)
+
+	var canonicalHand, canonicalBoard Cards
+	for i, s := range suits {
+		canonicalHand |= s.hand << (13 * i)
+		canonicalBoard |= s.board << (13 * i)
+	}
+
+	return canonicalHand, canonicalBoard
+}
+
+// indexedSuit is the cards of one suit moved to the first suit, with its count and index.
+type indexedSuit struct {
+	hand, board Cards
+	count       suitCount
+	index       uint64
+}
+
+// sortedSuits returns the cards of each suit sorted by number of cards, and then by index (both from greater to lower).
+func sortedSuits(hand, board Cards) [4]indexedSuit {
+	var suits [4]indexedSuit
+	for i := range suits {
+		h := (hand >> (13 * i)) & FIRST_SUIT
+		b := (board >> (13 * i)) & FIRST_SUIT
+		count := suitCount{h.Count(), b.Count()}
+
+		suits[i] = indexedSuit{
+			hand:  h,
+			board: b,
+			count: count,
+			index: colexIndex(h)*binomial(uint64(13-count.hand), count.board) + colexIndex(compressRanks(b, h)),
+		}
+	}
+
+	sort.Slice(suits[:], func(i, j int) bool {
+		if suits[i].count != suits[j].count {
+			return suits[j].count.less(suits[i].count)
+		}
+		return suits[i].index > suits[j].index
+	})
+
+	return suits
+}
+
+// suitUnindex returns the cards of the first suit with that index (the inverse of the index in sortedSuits).
+func suitUnindex(index uint64, count suitCount) (hand, board Cards) {
+	boardSize := binomial(uint64(13-count.hand), count.board)
+	hand = colexUnindex(index/boardSize, count.hand)
+	board = expandRanks(colexUnindex(index%boardSize, count.board), hand)
+
+	return hand, board
+}
+
+// less compares first by cards in the hand, and then by cards in the board.
+func (sc suitCount) less(other suitCount) bool {
+	if sc.hand != other.hand {
+		return sc.hand < other.hand
+	}
+
+	return sc.board < other.board
+}
+
+// colexIndex returns the position of the set of ranks (in the first suit) in colexicographical order,
+// between all the sets with the same number of ranks.
+func colexIndex(ranks Cards) uint64 {
+	var index uint64
+	i := 1
+	for rank := 0; rank < 13; rank++ {
+		if ranks.HasBit(rank) {
+			index += binomial(uint64(rank), i)
+			i++
+		}
+	}
+
+	return index
+}
+
+// colexUnindex returns the set of n ranks (in the first suit) in the position index (inverse of colexIndex).
+func colexUnindex(index uint64, n int) Cards {
+	ranks := NO_CARD
+	for i := n; i > 0; i-- {
+		rank := i - 1
+		for binomial(uint64(rank+1), i) <= index {
+			rank++
+		}
+
+		index -= binomial(uint64(rank), i)
+		ranks = ranks.SetBit(rank)
+	}
+
+	return ranks
+}
+
+// compressRanks removes the ranks in `used` from `ranks`, moving down the ranks above them.
+func compressRanks(ranks, used Cards) Cards {
+	compressed := NO_CARD
+	pos := 0
+	for rank := 0; rank < 13; rank++ {
+		if used.HasBit(rank) {
+			continue
+		}
+		if ranks.HasBit(rank) {
+			compressed = compressed.SetBit(pos)
+		}
+		pos++
+	}
+
+	return compressed
+}
+
+// expandRanks is the inverse of compressRanks.
+func expandRanks(compressed, used Cards) Cards {
+	ranks := NO_CARD
+	pos := 0
+	for rank := 0; rank < 13; rank++ {
+		if used.HasBit(rank) {
+			continue
+		}
+		if compressed.HasBit(pos) {
+			ranks = ranks.SetBit(rank)
+		}
+		pos++
+	}
+
+	return ranks
+}
+
+// multisetIndex returns the position of the multiset of values in colexicographical order,
+// between all the multisets with the same number of values.
+func multisetIndex(values []uint64) uint64 {
+	sorted := append([]uint64(nil), values...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	var index uint64
+	for i, v := range sorted {
+		index += binomial(v+uint64(i), i+1)
+	}
+
+	return index
+}
+
+// multisetUnindex returns the n values (sorted from greater to lower) of the multiset in the position index
+// (inverse of multisetIndex).
+func multisetUnindex(index uint64, n int) []uint64 {
+	values := make([]uint64, n)
+	for i := n; i > 0; i-- {
+		// biggest c that binomial(c, i) <= index
+		lo, hi := uint64(i-1), uint64(i-1)+1
+		for binomial(hi, i) <= index {
+			hi *= 2
+		}
+		for lo+1 < hi {
+			mid := (lo + hi) / 2
+			if binomial(mid, i) <= index {
+				lo = mid
+			} else {
+				hi = mid
+			}
+		}
+
+		index -= binomial(lo, i)
+		values[n-i] = lo - uint64(i-1)
+	}
+
+	return values
+}
+
+// binomial returns n choose k, or 0 if k > n.
+func binomial(n uint64, k int) uint64 {
+	if k < 0 || uint64(k) > n {
+		return 0
+	}
+
+	result := uint64(1)
+	for i := 1; i <= k; i++ {
+		result = result * (n - uint64(k) + uint64(i)) / uint64(i)
+	}
+
+	return result
+}
@@ -0,0 +1,156 @@
+package poker_test
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/arturo-source/poker-engine"
+)
+
+// randomCards returns n random cards which are not in used.
+func randomCards(r *rand.Rand, n int, used poker.Cards) poker.Cards {
+	cards := poker.NO_CARD
+	for cards.Count() < n {
+		card := poker.NO_CARD.SetBit(r.Intn(poker.MAX_CARDS))
+		if !card.CardsArePresent(used | cards) {
+			cards |= card
+		}
+	}
+
+	return cards
+}
+
+// permuteSuits moves the cards of suit i to the suit perm[i].
+func permuteSuits(cards poker.Cards, perm []int) poker.Cards {
+	permuted := poker.NO_CARD
+	for i, to := range perm {
+		permuted |= ((cards >> (13 * i)) & poker.FIRST_SUIT) << (13 * to)
+	}
+
+	return permuted
+}
+
+func TestHandIndexerSizes(t *testing.T) {
+	tests := []struct {
+		state poker.BoardState
+		want  uint64
+	}{
+		{poker.PREFLOP, 169},
+		{poker.FLOP, 1286792},
+	}
+
+	for _, test := range tests {
+		got := poker.NewHandIndexer(test.state).Size()
+		if test.want != got {
+			t.Errorf("\nWant %d\nGot  %d", test.want, got)
+		}
+	}
+}
+
+func TestPreflopIndexesAreDifferent(t *testing.T) {
+	hi := poker.NewHandIndexer(poker.PREFLOP)
+	seen := make(map[uint64]bool)
+
+	for i := 0; i < poker.MAX_CARDS; i++ {
+		for j := i + 1; j < poker.MAX_CARDS; j++ {
+			index, err := hi.Index(poker.NO_CARD.SetBit(i).SetBit(j), poker.NO_CARD)
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			seen[index] = true
+		}
+	}
+
+	if len(seen) != 169 {
+		t.Errorf("\nWant %d\nGot  %d", 169, len(seen))
+	}
+}
+
+func TestIsomorphicHandsHaveTheSameIndex(t *testing.T) {
+	c := poker.NewCard
+	hi := poker.NewHandIndexer(poker.FLOP)
+
+	i1, _ := hi.Index(c("Ah")|c("Kh"), c("2c")|c("3c")|c("4d"))
+	i2, _ := hi.Index(c("As")|c("Ks"), c("2d")|c("3d")|c("4h"))
+	if i1 != i2 {
+		t.Errorf("\nWant %d\nGot  %d", i1, i2)
+	}
+
+	h1, b1 := poker.Canonicalize(c("Ah")|c("Kh"), c("2c")|c("3c")|c("4d"))
+	h2, b2 := poker.Canonicalize(c("As")|c("Ks"), c("2d")|c("3d")|c("4h"))
+	if h1 != h2 || b1 != b2 {
+		t.Errorf("\nWant %s%s\nGot  %s%s", h1, b1, h2, b2)
+	}
+
+	i3, _ := hi.Index(c("Ah")|c("Kd"), c("2c")|c("3c")|c("4d"))
+	if i1 == i3 {
+		t.Errorf("Different hands have the same index %d", i1)
+	}
+}
+
+func TestHandIndexerRoundTrip(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	perms := [][]int{{1, 0, 2, 3}, {3, 2, 1, 0}, {2, 3, 0, 1}, {1, 2, 3, 0}}
+	states := []poker.BoardState{poker.PREFLOP, poker.FLOP, poker.TURN, poker.RIVER}
+	boardCards := []int{0, 3, 4, 5}
+
+	for s, state := range states {
+		hi := poker.NewHandIndexer(state)
+
+		for i := 0; i < 500; i++ {
+			hand := randomCards(r, 2, poker.NO_CARD)
+			board := randomCards(r, boardCards[s], hand)
+
+			index, err := hi.Index(hand, board)
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			if index >= hi.Size() {
+				t.Fatalf("Index %d out of range %d", index, hi.Size())
+			}
+
+			canonicalHand, canonicalBoard := poker.Canonicalize(hand, board)
+			gotHand, gotBoard, err := hi.Unindex(index)
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			if gotHand != canonicalHand || gotBoard != canonicalBoard {
+				t.Fatalf("\nWant %s| %s\nGot  %s| %s", canonicalHand, canonicalBoard, gotHand, gotBoard)
+			}
+
+			perm := perms[i%len(perms)]
+			permutedIndex, _ := hi.Index(permuteSuits(hand, perm), permuteSuits(board, perm))
+			if index != permutedIndex {
+				t.Fatalf("Isomorphic hands %s| %s have different indexes", hand, board)
+			}
+		}
+
+		// every index gives back the same index
+		for i := 0; i < 500; i++ {
+			index := uint64(r.Int63n(int64(hi.Size())))
+			hand, board, _ := hi.Unindex(index)
+			got, err := hi.Index(hand, board)
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			if index != got {
+				t.Fatalf("\nWant %d\nGot  %d", index, got)
+			}
+		}
+	}
+}
+
+func TestHandIndexerErrors(t *testing.T) {
+	c := poker.NewCard
+	hi := poker.NewHandIndexer(poker.FLOP)
+
+	if _, err := hi.Index(c("Ah"), c("2c")|c("3c")|c("4d")); err == nil {
+		t.Errorf("Wanted an error. Got nil.")
+	}
+	if _, err := hi.Index(c("Ah")|c("2c"), c("2c")|c("3c")|c("4d")); err == nil {
+		t.Errorf("Wanted an error. Got nil.")
+	}
+	if _, _, err := hi.Unindex(hi.Size()); err == nil {
+		t.Errorf("Wanted an error. Got nil.")
+	}
+}
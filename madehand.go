@@ -0,0 +1,222 @@
+package poker
+
+// MadeHandKind is what a hand has made with the table cards, from the point of view of the hand
+// (TOP_PAIR, OVERPAIR, SET, etc.), instead of the five best cards as in HandKind.
+type MadeHandKind int
+
+const (
+	NOTHING MadeHandKind = iota
+	BOARD_PLAYED
+	UNDERPAIR
+	BOTTOM_PAIR
+	MIDDLE_PAIR
+	TOP_PAIR
+	OVERPAIR
+	TWO_PAIR
+	TRIPS
+	SET
+	MADE_STRAIGHT
+	MADE_FLUSH
+	NUT_FLUSH
+	MADE_FULL_HOUSE
+	QUADS
+	MADE_STRAIGHT_FLUSH
+)
+
+func (mhk MadeHandKind) String() string {
+	names := [...]string{
+		"Nothing",
+		"Board played",
+		"Underpair",
+		"Bottom pair",
+		"Middle pair",
+		"Top pair",
+		"Overpair",
+		"Two pair",
+		"Trips",
+		"Set",
+		"Straight",
+		"Flush",
+		"Nut flush",
+		"Full house",
+		"Quads",
+		"Straight flush",
+	}
+
+	if mhk < NOTHING || mhk > MADE_STRAIGHT_FLUSH {
+		return "Unknown MadeHandKind"
+	}
+
+	return names[mhk]
+}
+
+// KickerClass is how good the kicker of a pair or trips made with only one card of the hand is.
+type KickerClass int
+
+const (
+	NO_KICKER KickerClass = iota
+	WEAK_KICKER
+	GOOD_KICKER
+	TOP_KICKER
+)
+
+func (kc KickerClass) String() string {
+	names := [...]string{
+		"No kicker",
+		"Weak kicker",
+		"Good kicker",
+		"Top kicker",
+	}
+
+	if kc < NO_KICKER || kc > TOP_KICKER {
+		return "Unknown KickerClass"
+	}
+
+	return names[kc]
+}
+
+// MadeHand is the classification of a hand relative to the table cards.
+// Kicker is only set for TOP_PAIR, MIDDLE_PAIR, BOTTOM_PAIR and TRIPS.
+type MadeHand struct {
+	Kind     MadeHandKind
+	Kicker   KickerClass
+	HandKind HandKind
+}
+
+// ClassifyMadeHand returns what the hand (two cards) has made with the table cards.
+//
+// Pairs made with a card of the hand are TOP_PAIR, MIDDLE_PAIR or BOTTOM_PAIR depending on the table card they pair,
+// and pocket pairs are OVERPAIR if they are higher than every table card, or UNDERPAIR if not.
+// A pocket pair with a table card is a SET, and one card of the hand with a pair in the table is TRIPS.
+// TWO_PAIR is only when both cards of the hand are paired (with a pair in the table, the pair of the hand is used).
+// A flush is NUT_FLUSH when nobody can have a higher one.
+// BOARD_PLAYED is when the five table cards are as good as the hand, and NOTHING when the hand doesn't make anything.
+func ClassifyMadeHand(hand Cards, tableCards []Cards) MadeHand {
+	board := JoinCards(tableCards...)
+	player := &Player{Hand: hand}
+	_, handKind := BestHand(player, board)
+	made := MadeHand{Kind: NOTHING, HandKind: handKind}
+
+	if board.Count() == MAX_CARDS_IN_BOARD {
+		if winners := GetWinners(board, []*Player{player, {}}); len(winners) == 2 {
+			made.Kind = BOARD_PLAYED
+			return made
+		}
+	}
+
+	cards := hand.Split()
+	if len(cards) != 2 {
+		return made
+	}
+	high, low := cards[0], cards[1]
+	pocketPair := cardRank(high) == cardRank(low)
+	onBoard := func(card Cards) int {
+		return (board & card.mergeSuits().expandToAllSuits()).Count()
+	}
+
+	switch handKind {
+	case ROYALFLUSH, STRAIGHTFLUSH:
+		made.Kind = MADE_STRAIGHT_FLUSH
+	case FOUROFAKIND:
+		made.Kind = QUADS
+	case FULLHOUSE:
+		made.Kind = MADE_FULL_HOUSE
+	case FLUSH:
+		made.Kind = MADE_FLUSH
+		if isNutFlush(hand, board) {
+			made.Kind = NUT_FLUSH
+		}
+	case STRAIGHT:
+		made.Kind = MADE_STRAIGHT
+	case THREEOFAKIND:
+		switch {
+		case pocketPair && onBoard(high) > 0:
+			made.Kind = SET
+		case onBoard(high) == 2:
+			made.Kind, made.Kicker = TRIPS, kickerClass(low, high, board)
+		case onBoard(low) == 2:
+			made.Kind, made.Kicker = TRIPS, kickerClass(high, low, board)
+		}
+	case TWOPAIR:
+		if !pocketPair && onBoard(high) == 1 && onBoard(low) == 1 {
+			made.Kind = TWO_PAIR
+			break
+		}
+		made.Kind, made.Kicker = classifyPair(high, low, board)
+	case PAIR:
+		made.Kind, made.Kicker = classifyPair(high, low, board)
+	}
+
+	return made
+}
+
+// classifyPair returns which pair the hand makes (high is the highest card of the hand, and low the other one).
+func classifyPair(high, low, board Cards) (MadeHandKind, KickerClass) {
+	boardRanks := board.mergeSuits()
+	highestBoard, _ := HighCard(boardRanks)
+	lowestBoard := boardRanks & -boardRanks
+
+	if cardRank(high) == cardRank(low) {
+		if high.mergeSuits() > highestBoard {
+			return OVERPAIR, NO_KICKER
+		}
+		return UNDERPAIR, NO_KICKER
+	}
+
+	paired, kicker := high, low
+	if !boardRanks.CardsArePresent(high.mergeSuits()) {
+		paired, kicker = low, high
+	}
+	if !boardRanks.CardsArePresent(paired.mergeSuits()) {
+		return NOTHING, NO_KICKER
+	}
+
+	kind := MIDDLE_PAIR
+	switch paired.mergeSuits() {
+	case highestBoard:
+		kind = TOP_PAIR
+	case lowestBoard:
+		kind = BOTTOM_PAIR
+	}
+
+	return kind, kickerClass(kicker, paired, board)
+}
+
+// kickerClass returns TOP_KICKER if there is no better kicker possible,
+// GOOD_KICKER if there are one or two better, and WEAK_KICKER if there are more.
+func kickerClass(kicker, paired, board Cards) KickerClass {
+	used := board.mergeSuits() | paired.mergeSuits()
+	better := 0
+	for rank := cardRank(kicker) + 1; rank < 13; rank++ {
+		if !used.HasBit(rank) {
+			better++
+		}
+	}
+
+	switch {
+	case better == 0:
+		return TOP_KICKER
+	case better <= 2:
+		return GOOD_KICKER
+	default:
+		return WEAK_KICKER
+	}
+}
+
+// isNutFlush returns true if the hand has the highest card of the flush suit which is not in the table.
+func isNutFlush(hand, board Cards) bool {
+	for _, suit := range []Cards{CLUBS, DIAMONDS, HEARTS, SPADES} {
+		if (JoinCards(hand, board) & suit).Count() < 5 {
+			continue
+		}
+
+		for num := ACES; num >= TWOS; num >>= 1 {
+			card := num & suit
+			if !board.CardsArePresent(card) {
+				return hand.CardsArePresent(card)
+			}
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,44 @@
+package poker_test
+
+import (
+	"testing"
+
+	"github.com/arturo-source/poker-engine"
+)
+
+func TestClassifyMadeHand(t *testing.T) {
+	c := poker.NewCard
+	flop := []poker.Cards{c("Kh"), c("8d"), c("4c")}
+	pairedFlop := []poker.Cards{c("Kh"), c("Kd"), c("4c")}
+
+	tests := []struct {
+		hand       poker.Cards
+		tableCards []poker.Cards
+		want       poker.MadeHand
+	}{
+		{c("As") | c("Ad"), flop, poker.MadeHand{Kind: poker.OVERPAIR, HandKind: poker.PAIR}},
+		{c("5s") | c("5d"), flop, poker.MadeHand{Kind: poker.UNDERPAIR, HandKind: poker.PAIR}},
+		{c("Ks") | c("Ac"), flop, poker.MadeHand{Kind: poker.TOP_PAIR, Kicker: poker.TOP_KICKER, HandKind: poker.PAIR}},
+		{c("Ks") | c("Jc"), flop, poker.MadeHand{Kind: poker.TOP_PAIR, Kicker: poker.GOOD_KICKER, HandKind: poker.PAIR}},
+		{c("Ks") | c("6c"), flop, poker.MadeHand{Kind: poker.TOP_PAIR, Kicker: poker.WEAK_KICKER, HandKind: poker.PAIR}},
+		{c("8s") | c("Ac"), flop, poker.MadeHand{Kind: poker.MIDDLE_PAIR, Kicker: poker.TOP_KICKER, HandKind: poker.PAIR}},
+		{c("4s") | c("Qc"), flop, poker.MadeHand{Kind: poker.BOTTOM_PAIR, Kicker: poker.GOOD_KICKER, HandKind: poker.PAIR}},
+		{c("Ks") | c("8c"), flop, poker.MadeHand{Kind: poker.TWO_PAIR, HandKind: poker.TWOPAIR}},
+		{c("8s") | c("8c"), flop, poker.MadeHand{Kind: poker.SET, HandKind: poker.THREEOFAKIND}},
+		{c("Ks") | c("Ac"), pairedFlop, poker.MadeHand{Kind: poker.TRIPS, Kicker: poker.TOP_KICKER, HandKind: poker.THREEOFAKIND}},
+		{c("4s") | c("Ac"), pairedFlop, poker.MadeHand{Kind: poker.BOTTOM_PAIR, Kicker: poker.TOP_KICKER, HandKind: poker.TWOPAIR}},
+		{c("Qs") | c("Jc"), pairedFlop, poker.MadeHand{Kind: poker.NOTHING, HandKind: poker.PAIR}},
+		{c("7s") | c("6c"), []poker.Cards{c("5h"), c("8d"), c("4c")}, poker.MadeHand{Kind: poker.MADE_STRAIGHT, HandKind: poker.STRAIGHT}},
+		{c("Ah") | c("2h"), []poker.Cards{c("Kh"), c("8h"), c("4h")}, poker.MadeHand{Kind: poker.NUT_FLUSH, HandKind: poker.FLUSH}},
+		{c("Qh") | c("2h"), []poker.Cards{c("Kh"), c("8h"), c("4h")}, poker.MadeHand{Kind: poker.MADE_FLUSH, HandKind: poker.FLUSH}},
+		{c("Qh") | c("2c"), []poker.Cards{c("Ah"), c("Kh"), c("8h"), c("4h")}, poker.MadeHand{Kind: poker.NUT_FLUSH, HandKind: poker.FLUSH}},
+		{c("2s") | c("3c"), []poker.Cards{c("Ah"), c("Kd"), c("Qs"), c("Jc"), c("Th")}, poker.MadeHand{Kind: poker.BOARD_PLAYED, HandKind: poker.STRAIGHT}},
+	}
+
+	for _, test := range tests {
+		got := poker.ClassifyMadeHand(test.hand, test.tableCards)
+		if test.want != got {
+			t.Errorf("%s on %v\nWant %+v\nGot  %+v", test.hand, test.tableCards, test.want, got)
+		}
+	}
+}
@@ -0,0 +1,240 @@
+package poker
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"sync"
+)
+
+// classMatchups has, for every pair of classes, the number of pairs of hands of them which don't share cards,
+// and the all-in equity of the first class against the second one.
+type classMatchups struct {
+	combos [HAND_CLASSES][HAND_CLASSES]float64
+	equity [HAND_CLASSES][HAND_CLASSES]float64
+}
+
+var (
+	matchups     classMatchups
+	matchupsErr  error
+	matchupsOnce sync.Once
+)
+
+// preflopMatchups returns the matchups between classes, computing them the first time
+// with the PreflopEquity of every pair of hands.
+func preflopMatchups() (*classMatchups, error) {
+	matchupsOnce.Do(func() {
+		hands := FullRange().sortedHands()
+		classes := make([]HandClass, len(hands))
+		for i, hand := range hands {
+			classes[i] = handClass(hand)
+		}
+
+		var equities [HAND_CLASSES][HAND_CLASSES]float64
+		for i := range hands {
+			for j := i + 1; j < len(hands); j++ {
+				if hands[i].CardsArePresent(hands[j]) {
+					continue
+				}
+
+				equity, err := PreflopEquity(hands[i], hands[j])
+				if err != nil {
+					matchupsErr = err
+					return
+				}
+
+				ci, cj := classes[i], classes[j]
+				matchups.combos[ci][cj]++
+				matchups.combos[cj][ci]++
+				equities[ci][cj] += equity
+				equities[cj][ci] += 1 - equity
+			}
+		}
+
+		for i := range equities {
+			for j := range equities[i] {
+				matchups.equity[i][j] = equities[i][j] / matchups.combos[i][j]
+			}
+		}
+	})
+
+	return &matchups, matchupsErr
+}
+
+// sortedHands returns the hands of the range with some weight, sorted.
+func (r Range) sortedHands() []Cards {
+	hands, _ := r.hands(NO_CARD)
+	return hands
+}
+
+// PushFoldConfig is a heads-up spot where the small blind can only go all-in or fold,
+// and the big blind can only call the all-in or fold.
+// Stack is the effective stack in big blinds (before posting the blinds and antes),
+// and Ante the ante of each player, also in big blinds.
+// The equilibrium is found with Iterations iterations of fictitious play (2000 if it is 0).
+type PushFoldConfig struct {
+	Stack      float64
+	Ante       float64
+	Iterations int
+}
+
+// PushFoldEquilibrium are the ranges of a push/fold Nash equilibrium:
+// Push has how often the small blind goes all-in with each hand, and Call how often the big blind calls
+// (rounded to the percent).
+// EV is what the small blind wins in average, in big blinds, and Exploitability how much more (in average between
+// both players) a player could win knowing the opponent's strategy.
+type PushFoldEquilibrium struct {
+	Stack          float64
+	Ante           float64
+	Push           Range
+	Call           Range
+	EV             float64
+	Exploitability float64
+}
+
+// SolvePushFold computes the push/fold Nash equilibrium of the spot, with the all-in equities of the hand classes
+// (so every hand of a class is played the same way).
+// Returns an error if the table of preflop equities can't be read.
+func SolvePushFold(config PushFoldConfig) (PushFoldEquilibrium, error) {
+	iterations := config.Iterations
+	if iterations == 0 {
+		iterations = 2000
+	}
+
+	m, err := preflopMatchups()
+	if err != nil {
+		return PushFoldEquilibrium{}, err
+	}
+
+	pf := pushFold{matchups: m, stack: config.Stack, ante: config.Ante}
+	var push, call [HAND_CLASSES]float64
+	for i := range push {
+		push[i], call[i] = 1, 1
+	}
+
+	for t := 1; t <= iterations; t++ {
+		bestPush, _ := pf.bestPush(call)
+		bestCall, _ := pf.bestCall(push)
+		for i := range push {
+			push[i] += (bestPush[i] - push[i]) / float64(t+1)
+			call[i] += (bestCall[i] - call[i]) / float64(t+1)
+		}
+	}
+
+	ev := pf.ev(push, call)
+	_, bestPushEV := pf.bestPush(call)
+	_, bestCallEV := pf.bestCall(push)
+
+	return PushFoldEquilibrium{
+		Stack:          config.Stack,
+		Ante:           config.Ante,
+		Push:           classRange(push),
+		Call:           classRange(call),
+		EV:             ev,
+		Exploitability: (bestPushEV - bestCallEV) / 2,
+	}, nil
+}
+
+// pushFold computes the EVs of the small blind (in big blinds) in a push/fold spot.
+type pushFold struct {
+	matchups    *classMatchups
+	stack, ante float64
+}
+
+// showdown returns what the small blind wins with the class when the big blind calls with the other one.
+func (pf pushFold) showdown(sb, bb int) float64 {
+	return (2*pf.matchups.equity[sb][bb] - 1) * pf.stack
+}
+
+// bestPush returns the best response of the small blind to the calling frequencies, and its EV.
+func (pf pushFold) bestPush(call [HAND_CLASSES]float64) ([HAND_CLASSES]float64, float64) {
+	var push [HAND_CLASSES]float64
+	var ev, combos float64
+	fold := -(0.5 + pf.ante)
+	steal := 1 + pf.ante
+	for sb := range push {
+		var pushEV, total float64
+		for bb, freq := range call {
+			w := pf.matchups.combos[sb][bb]
+			pushEV += w * (freq*pf.showdown(sb, bb) + (1-freq)*steal)
+			total += w
+		}
+		pushEV /= total
+
+		best := fold
+		if pushEV > fold {
+			push[sb], best = 1, pushEV
+		}
+		ev += best * total
+		combos += total
+	}
+
+	return push, ev / combos
+}
+
+// bestCall returns the best response of the big blind to the pushing frequencies, and the small blind's EV.
+func (pf pushFold) bestCall(push [HAND_CLASSES]float64) ([HAND_CLASSES]float64, float64) {
+	var call [HAND_CLASSES]float64
+	var ev, combos float64
+	fold := -(0.5 + pf.ante)
+	steal := 1 + pf.ante
+	for bb := range call {
+		var callEV, foldEV float64
+		for sb, freq := range push {
+			w := pf.matchups.combos[sb][bb]
+			callEV += w * freq * pf.showdown(sb, bb)
+			foldEV += w * freq * steal
+			ev += w * (1 - freq) * fold
+			combos += w
+		}
+
+		// The small blind wins less when the big blind calls
+		if callEV < foldEV {
+			call[bb] = 1
+			ev += callEV
+		} else {
+			ev += foldEV
+		}
+	}
+
+	return call, ev / combos
+}
+
+// ev returns the EV of the small blind when both players play with those frequencies.
+func (pf pushFold) ev(push, call [HAND_CLASSES]float64) float64 {
+	var ev, combos float64
+	fold := -(0.5 + pf.ante)
+	steal := 1 + pf.ante
+	for sb := range push {
+		for bb := range call {
+			w := pf.matchups.combos[sb][bb]
+			pushEV := call[bb]*pf.showdown(sb, bb) + (1-call[bb])*steal
+			ev += w * (push[sb]*pushEV + (1-push[sb])*fold)
+			combos += w
+		}
+	}
+
+	return ev / combos
+}
+
+// classRange returns the range with every hand of each class with its frequency rounded to the percent,
+// which removes what is left of the first strategies of the fictitious play (the classes with 0 are left out).
+func classRange(freqs [HAND_CLASSES]float64) Range {
+	var grid HandGrid
+	for hc, freq := range freqs {
+		grid[hc] = math.Round(freq*100) / 100
+	}
+
+	return grid.Range()
+}
+
+// Chart returns the push and call ranges in two 13x13 grids, with the percentage of the times each class is played.
+func (e PushFoldEquilibrium) Chart() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Small blind pushes (%gbb, ante %gbb)\n", e.Stack, e.Ante)
+	sb.WriteString(RangeGrid(e.Push).String())
+	fmt.Fprintf(&sb, "\nBig blind calls (%gbb, ante %gbb)\n", e.Stack, e.Ante)
+	sb.WriteString(RangeGrid(e.Call).String())
+
+	return sb.String()
+}
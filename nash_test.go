@@ -0,0 +1,82 @@
+package poker_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/arturo-source/poker-engine"
+)
+
+func solvePushFold(t *testing.T, config poker.PushFoldConfig) poker.PushFoldEquilibrium {
+	t.Helper()
+	e, err := poker.SolvePushFold(config)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	return e
+}
+
+func TestPushFoldEquilibrium(t *testing.T) {
+	c := poker.NewCard
+	e := solvePushFold(t, poker.PushFoldConfig{Stack: 10})
+
+	if e.Exploitability > 0.01 {
+		t.Errorf("The equilibrium shouldn't be exploitable: %f", e.Exploitability)
+	}
+	if push := e.Push.Combos() / 1326; push < 0.5 || push > 0.65 {
+		t.Errorf("With 10bb the small blind should push around 58%% of the hands: %f", push)
+	}
+	if call := e.Call.Combos() / 1326; call < 0.3 || call > 0.45 {
+		t.Errorf("With 10bb the big blind should call around 37%% of the hands: %f", call)
+	}
+
+	aces, sevenDeuce := c("Ah")|c("As"), c("7d")|c("2c")
+	if e.Push[aces] != 1 || e.Call[aces] != 1 {
+		t.Errorf("Aces should always be pushed and called")
+	}
+	if e.Push[sevenDeuce] != 0 || e.Call[sevenDeuce] != 0 {
+		t.Errorf("Seven-deuce offsuit should always be folded")
+	}
+}
+
+func TestPushFoldRangesByStackAndAnte(t *testing.T) {
+	short := solvePushFold(t, poker.PushFoldConfig{Stack: 1})
+	if short.Push.Combos() != 1326 || short.Call.Combos() != 1326 {
+		t.Errorf("With 1bb every hand should be pushed and called: %f %f", short.Push.Combos(), short.Call.Combos())
+	}
+
+	previous := short
+	for _, stack := range []float64{5, 10, 20} {
+		e := solvePushFold(t, poker.PushFoldConfig{Stack: stack})
+		if e.Push.Combos() >= previous.Push.Combos() || e.Call.Combos() >= previous.Call.Combos() {
+			t.Errorf("The ranges should be tighter with %gbb than with %gbb", stack, previous.Stack)
+		}
+		previous = e
+	}
+
+	noAnte := solvePushFold(t, poker.PushFoldConfig{Stack: 15})
+	ante := solvePushFold(t, poker.PushFoldConfig{Stack: 15, Ante: 0.125})
+	if ante.Push.Combos() <= noAnte.Push.Combos() {
+		t.Errorf("The antes should make the small blind push more hands: %f %f", ante.Push.Combos(), noAnte.Push.Combos())
+	}
+}
+
+func TestPushFoldChart(t *testing.T) {
+	chart := solvePushFold(t, poker.PushFoldConfig{Stack: 10}).Chart()
+	lines := strings.Split(chart, "\n")
+
+	if !strings.HasPrefix(lines[0], "Small blind pushes (10bb") || !strings.HasPrefix(lines[16], "Big blind calls (10bb") {
+		t.Errorf("Wrong chart titles:\n%s", chart)
+	}
+	if fields := strings.Fields(lines[1]); len(fields) != 13 || fields[0] != "A" || fields[12] != "2" {
+		t.Errorf("Wrong header: %q", lines[1])
+	}
+	// AA in the first cell, and 32o in the last one
+	if fields := strings.Fields(lines[2]); fields[0] != "A" || fields[1] != "100" {
+		t.Errorf("Wrong aces row: %q", lines[2])
+	}
+	if fields := strings.Fields(lines[14]); fields[0] != "2" || fields[13] != "100" || fields[12] != "0" {
+		t.Errorf("Wrong deuces row: %q", lines[14])
+	}
+}
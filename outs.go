@@ -0,0 +1,185 @@
+package poker
+
+import "errors"
+
+var errWrongBoardForOuts = errors.New("outs can only be found in the FLOP or the TURN")
+
+// DrawKind is a kind of draw (FLUSH_DRAW, OPEN_ENDED, etc.).
+type DrawKind int
+
+const (
+	FLUSH_DRAW DrawKind = iota
+	OPEN_ENDED
+	GUTSHOT
+	BACKDOOR_FLUSH
+	BACKDOOR_STRAIGHT
+	OVERCARDS
+)
+
+func (dk DrawKind) String() string {
+	names := [...]string{
+		"Flush draw",
+		"Open-ended straight draw",
+		"Gutshot",
+		"Backdoor flush draw",
+		"Backdoor straight draw",
+		"Overcards",
+	}
+
+	if dk < FLUSH_DRAW || dk > OVERCARDS {
+		return "Unknown DrawKind"
+	}
+
+	return names[dk]
+}
+
+// Draw is a draw of a hand, with the cards which complete it.
+// Backdoor draws need two cards, so their Outs are the cards that turn them into a normal draw.
+type Draw struct {
+	Kind DrawKind
+	Outs Cards
+}
+
+// Outs is what can improve a hand in the next card.
+// Cards are the outs: the cards which improve the HandKind of the hand more than the one of the table cards alone,
+// so a card which only pairs the table isn't an out, it improves everybody
+// (or, if there are opponents, the cards which make the hand the only winner when it isn't yet).
+type Outs struct {
+	Cards Cards
+	Draws []Draw
+}
+
+// FindOuts returns the outs of the hand with the table cards, in the FLOP or the TURN.
+// If the opponents' hands are known, they are removed from the outs, and the outs are the cards that make the hand win
+// when it is behind or tied (a hand which is already the only winner has no outs).
+// Returns an error if the table doesn't have 3 or 4 cards.
+func FindOuts(hand Cards, tableCards []Cards, opponents ...Cards) (Outs, error) {
+	board := JoinCards(tableCards...)
+	if board.Count() != 3 && board.Count() != 4 {
+		return Outs{}, errWrongBoardForOuts
+	}
+
+	dead := JoinCards(opponents...) | hand | board
+	outs := Outs{}
+
+	player := &Player{Hand: hand}
+	_, currentKind := BestHand(player, board)
+	_, boardKind := BestHand(&Player{}, board)
+
+	players := []*Player{player}
+	for _, opponent := range opponents {
+		players = append(players, &Player{Hand: opponent})
+	}
+	onlyWinner := func(board Cards) bool {
+		winners := GetWinners(board, players)
+		return len(winners) == 1 && winners[0].Player == player
+	}
+	ahead := len(opponents) > 0 && onlyWinner(board)
+
+	for _, card := range ALL_CARDS.QuitCards(dead).Split() {
+		newBoard := board | card
+		if len(opponents) > 0 {
+			if !ahead && onlyWinner(newBoard) {
+				outs.Cards |= card
+			}
+			continue
+		}
+
+		// How many kinds the hand is above the table must grow
+		_, newKind := BestHand(player, newBoard)
+		_, newBoardKind := BestHand(&Player{}, newBoard)
+		if newKind-newBoardKind > currentKind-boardKind {
+			outs.Cards |= card
+		}
+	}
+
+	outs.Draws = findDraws(hand, board, dead, currentKind)
+	return outs, nil
+}
+
+// findDraws classifies the draws of the hand, whose outs are not in dead.
+func findDraws(hand, board, dead Cards, currentKind HandKind) []Draw {
+	draws := make([]Draw, 0)
+	allCards := hand | board
+	live := ALL_CARDS.QuitCards(dead)
+
+	// Flush draws, using at least one card of the hand
+	if currentKind < FLUSH {
+		for _, suit := range []Cards{CLUBS, DIAMONDS, HEARTS, SPADES} {
+			if !hand.CardsArePresent(suit) {
+				continue
+			}
+
+			switch (allCards & suit).Count() {
+			case 4:
+				draws = append(draws, Draw{FLUSH_DRAW, live & suit})
+			case 3:
+				if board.Count() == 3 {
+					draws = append(draws, Draw{BACKDOOR_FLUSH, live & suit})
+				}
+			}
+		}
+	}
+
+	// Straight draws, the straight must use at least one card of the hand.
+	// Two ranks to complete it is OPEN_ENDED (double gutshots too), and only one is a GUTSHOT
+	if currentKind < STRAIGHT {
+		completes := func(cards, board Cards) bool {
+			_, withHand := Straight(cards)
+			_, onlyBoard := Straight(board)
+			return withHand && !onlyBoard
+		}
+
+		var straightOuts, backdoorOuts Cards
+		straightRanks := 0
+		for num := ACES; num >= TWOS; num >>= 1 {
+			card := num & FIRST_SUIT
+			if allCards.CardsArePresent(num) {
+				continue
+			}
+
+			if completes(allCards.mergeSuits()|card, board.mergeSuits()|card) {
+				straightOuts |= live & num
+				straightRanks++
+				continue
+			}
+
+			if board.Count() == 3 {
+				for num2 := ACES; num2 >= TWOS; num2 >>= 1 {
+					card2 := num2 & FIRST_SUIT
+					if num2 != num && !allCards.CardsArePresent(num2) &&
+						completes(allCards.mergeSuits()|card|card2, board.mergeSuits()|card|card2) {
+						backdoorOuts |= live & num
+						break
+					}
+				}
+			}
+		}
+
+		switch {
+		case straightRanks >= 2:
+			draws = append(draws, Draw{OPEN_ENDED, straightOuts})
+		case straightRanks == 1:
+			draws = append(draws, Draw{GUTSHOT, straightOuts})
+		case backdoorOuts != NO_CARD:
+			draws = append(draws, Draw{BACKDOOR_STRAIGHT, backdoorOuts})
+		}
+	}
+
+	// Overcards, hand cards higher than every table card, without pair
+	if currentKind == HIGHCARD {
+		highestBoard, _ := HighCard(board)
+		var overcards Cards
+		for _, card := range hand.Split() {
+			if card.mergeSuits() > highestBoard.mergeSuits() {
+				overcards |= live & card.mergeSuits().expandToAllSuits()
+			}
+		}
+
+		if overcards != NO_CARD {
+			draws = append(draws, Draw{OVERCARDS, overcards})
+		}
+	}
+
+	return draws
+}
@@ -0,0 +1,152 @@
+package poker_test
+
+import (
+	"testing"
+
+	"github.com/arturo-source/poker-engine"
+)
+
+func findDraw(outs poker.Outs, kind poker.DrawKind) (poker.Draw, bool) {
+	for _, d := range outs.Draws {
+		if d.Kind == kind {
+			return d, true
+		}
+	}
+
+	return poker.Draw{}, false
+}
+
+func TestNineOutsToAFlush(t *testing.T) {
+	c := poker.NewCard
+	outs, err := poker.FindOuts(c("Ah")|c("2h"), []poker.Cards{c("Kh"), c("7h"), c("9c")})
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	draw, found := findDraw(outs, poker.FLUSH_DRAW)
+	if !found {
+		t.Fatalf("Flush draw not found: %v", outs.Draws)
+	}
+
+	want := 9
+	got := draw.Outs.Count()
+	if want != got {
+		t.Errorf("\nWant %d\nGot  %d", want, got)
+	}
+	if !outs.Cards.CardsArePresent(c("3h")) {
+		t.Errorf("3h should be an out")
+	}
+}
+
+func TestOpenEndedAndGutshot(t *testing.T) {
+	c := poker.NewCard
+
+	outs, _ := poker.FindOuts(c("8s")|c("7d"), []poker.Cards{c("6h"), c("5c"), c("Kd")})
+	draw, found := findDraw(outs, poker.OPEN_ENDED)
+	if !found || draw.Outs.Count() != 8 {
+		t.Errorf("Open-ended draw with 8 outs not found: %v", outs.Draws)
+	}
+
+	outs, _ = poker.FindOuts(c("8s")|c("7d"), []poker.Cards{c("5h"), c("4c"), c("Kd")})
+	draw, found = findDraw(outs, poker.GUTSHOT)
+	if !found || draw.Outs.Count() != 4 || !draw.Outs.CardsArePresent(c("6s")) {
+		t.Errorf("Gutshot with 4 outs not found: %v", outs.Draws)
+	}
+}
+
+func TestBackdoorDrawsAndOvercards(t *testing.T) {
+	c := poker.NewCard
+	outs, _ := poker.FindOuts(c("Ah")|c("Kh"), []poker.Cards{c("Jh"), c("4c"), c("2d")})
+
+	if _, found := findDraw(outs, poker.BACKDOOR_FLUSH); !found {
+		t.Errorf("Backdoor flush draw not found: %v", outs.Draws)
+	}
+	if _, found := findDraw(outs, poker.BACKDOOR_STRAIGHT); !found {
+		t.Errorf("Backdoor straight draw not found: %v", outs.Draws)
+	}
+
+	draw, found := findDraw(outs, poker.OVERCARDS)
+	if !found || draw.Outs.Count() != 6 {
+		t.Errorf("Overcards with 6 outs not found: %v", outs.Draws)
+	}
+}
+
+func TestBoardDrawIsNotAnOut(t *testing.T) {
+	c := poker.NewCard
+	outs, _ := poker.FindOuts(c("Ac")|c("2d"), []poker.Cards{c("9h"), c("8h"), c("7h"), c("6h")})
+
+	if _, found := findDraw(outs, poker.FLUSH_DRAW); found {
+		t.Errorf("Flush draw without cards of the hand")
+	}
+	if _, found := findDraw(outs, poker.OPEN_ENDED); found {
+		t.Errorf("Straight draw without cards of the hand")
+	}
+}
+
+func TestPairingTheTableIsNotAnOut(t *testing.T) {
+	c := poker.NewCard
+	tests := []struct {
+		hand       poker.Cards
+		tableCards []poker.Cards
+		outs       poker.Cards
+	}{
+		// A pocket pair under two overcards only improves with a set,
+		// the aces, kings and deuces give two pair to everybody
+		{c("7d") | c("7s"), []poker.Cards{c("Ac"), c("Kh"), c("2c")}, c("7h") | c("7c")},
+		// Top pair improves with two pair or trips, not pairing the 7 or the 2
+		{c("Ah") | c("Qd"), []poker.Cards{c("Ac"), c("7d"), c("2s")}, c("As") | c("Ad") | c("Qs") | c("Qh") | c("Qc")},
+		// A set improves to a full house when the table pairs, and to four of a kind
+		{c("7d") | c("7s"), []poker.Cards{c("7c"), c("Kh"), c("2c")},
+			c("7h") | c("Ks") | c("Kd") | c("Kc") | c("2s") | c("2h") | c("2d")},
+	}
+
+	for _, tt := range tests {
+		outs, err := poker.FindOuts(tt.hand, tt.tableCards)
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if outs.Cards != tt.outs {
+			t.Errorf("%s on %v\nWant %s\nGot  %s", tt.hand, tt.tableCards, tt.outs, outs.Cards)
+		}
+	}
+}
+
+func TestOutsAgainstOpponent(t *testing.T) {
+	c := poker.NewCard
+	board := []poker.Cards{c("Kh"), c("7h"), c("2c"), c("3d")}
+
+	outs, err := poker.FindOuts(c("Ah")|c("5h"), board, c("Kc")|c("Qs"))
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	// 9 hearts, 3 fours for the straight (4h is a heart), and 3 aces for the best pair
+	want := 15
+	got := outs.Cards.Count()
+	if want != got {
+		t.Errorf("\nWant %d\nGot  %d (%s)", want, got, outs.Cards)
+	}
+}
+
+func TestNoOutsWhenAhead(t *testing.T) {
+	c := poker.NewCard
+	board := []poker.Cards{c("Kh"), c("7h"), c("2c"), c("3d")}
+
+	// A set of kings is already winning against the flush draw
+	outs, err := poker.FindOuts(c("Ks")|c("Kd"), board, c("Ah")|c("5h"))
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	if outs.Cards != poker.NO_CARD {
+		t.Errorf("A hand which is ahead has no outs, got %d (%s)", outs.Cards.Count(), outs.Cards)
+	}
+}
+
+func TestOutsNeedFlopOrTurn(t *testing.T) {
+	c := poker.NewCard
+	_, err := poker.FindOuts(c("Ah")|c("5h"), []poker.Cards{c("Kh")})
+	if err == nil {
+		t.Errorf("Wanted an error. Got nil.")
+	}
+}
@@ -0,0 +1,88 @@
+package poker
+
+import (
+	"math/bits"
+	"sort"
+)
+
+// OddChipRule decides who gets the coins left when a pot can't be split evenly between the winners.
+type OddChipRule int
+
+const (
+	// ODD_CHIP_LEFT_OF_BUTTON gives the odd coins to the first winners to the left of the button
+	ODD_CHIP_LEFT_OF_BUTTON OddChipRule = iota
+	// ODD_CHIP_HIGHEST_CARD gives the odd coins to the winners with the highest card in the hand,
+	// and if the ranks are the same, by suit (spades, hearts, diamonds and clubs)
+	ODD_CHIP_HIGHEST_CARD
+)
+
+func (ocr OddChipRule) String() string {
+	names := [...]string{
+		"Left of the button",
+		"Highest card",
+	}
+
+	if ocr < ODD_CHIP_LEFT_OF_BUTTON || ocr > ODD_CHIP_HIGHEST_CARD {
+		return "Unknown OddChipRule"
+	}
+
+	return names[ocr]
+}
+
+// Payout is what a player has won from a pot. Pot is the index of the pot in Game.Pots (0 is the main pot),
+// and Runout the index in Game.Runouts (always 0 if the board is run once).
+// OddCoins are the coins of Coins which were left when splitting the pot.
+type Payout struct {
+	Player   *Player
+	Pot      int
+	Runout   int
+	Coins    uint
+	OddCoins uint
+}
+
+// SplitPot divides the coins of a pot between the winners (the players who tie, as returned by GetWinners),
+// and gives the odd coins one by one following the OddChipRule of the game.
+// The payouts are returned in the order the odd coins are given, and the players' coins are not changed.
+func (g *Game) SplitPot(coins uint, winners []PlayerHandValue) []Payout {
+	if len(winners) == 0 {
+		return nil
+	}
+
+	players := make([]*Player, len(winners))
+	for i, w := range winners {
+		players[i] = w.Player
+	}
+	g.sortFromButton(players)
+
+	if g.OddChipRule == ODD_CHIP_HIGHEST_CARD {
+		sort.SliceStable(players, func(i, j int) bool {
+			return highestCard(players[i].Hand) > highestCard(players[j].Hand)
+		})
+	}
+
+	payouts := make([]Payout, len(players))
+	share := coins / uint(len(players))
+	oddCoins := coins % uint(len(players))
+	for i, p := range players {
+		payouts[i] = Payout{Player: p, Coins: share}
+		if uint(i) < oddCoins {
+			payouts[i].Coins++
+			payouts[i].OddCoins = 1
+		}
+	}
+
+	return payouts
+}
+
+// highestCard returns a number which is higher for higher cards, comparing by rank, and then by suit.
+func highestCard(hand Cards) int {
+	highest := -1
+	for _, card := range hand.Split() {
+		suit := bits.TrailingZeros64(uint64(card)) / 13
+		if value := cardRank(card)*4 + suit; value > highest {
+			highest = value
+		}
+	}
+
+	return highest
+}
@@ -0,0 +1,89 @@
+package poker_test
+
+import (
+	"testing"
+
+	"github.com/arturo-source/poker-engine"
+)
+
+// alwaysTie is hold'em where every hand ties.
+var alwaysTie = &poker.Variant{
+	Name:       "Always tie",
+	Deck:       poker.ALL_CARDS,
+	HandCards:  2,
+	TableCards: []int{3, 1, 1},
+	HandValue:  func(hand, tableCards poker.Cards) int { return 0 },
+}
+
+func TestSplitPotOddChipLeftOfButton(t *testing.T) {
+	c := poker.NewCard
+	g := newBettingGame(100, 100, 100)
+	g.Button = 0
+	g.Players[0].Hand = c("As") | c("Kd")
+	g.Players[2].Hand = c("Ah") | c("Kh")
+
+	winners := []poker.PlayerHandValue{{Player: g.Players[0]}, {Player: g.Players[2]}}
+	payouts := g.SplitPot(101, winners)
+
+	if len(payouts) != 2 || payouts[0].Player != g.Players[2] || payouts[0].Coins != 51 || payouts[0].OddCoins != 1 {
+		t.Errorf("The first winner to the left of the button should get the odd coin: %+v", payouts)
+	}
+	if payouts[1].Coins != 50 || payouts[1].OddCoins != 0 {
+		t.Errorf("Wrong payout: %+v", payouts[1])
+	}
+
+	g.OddChipRule = poker.ODD_CHIP_HIGHEST_CARD
+	payouts = g.SplitPot(101, winners)
+	if payouts[0].Player != g.Players[0] || payouts[0].Coins != 51 {
+		t.Errorf("The ace of spades should get the odd coin: %+v", payouts)
+	}
+}
+
+func TestSplitPotBetweenThree(t *testing.T) {
+	g := newBettingGame(100, 100, 100, 100)
+	g.Button = 2
+
+	winners := []poker.PlayerHandValue{{Player: g.Players[0]}, {Player: g.Players[1]}, {Player: g.Players[2]}}
+	payouts := g.SplitPot(20, winners)
+
+	// From the left of the button: seats 0, 1 and 2
+	want := []uint{7, 7, 6}
+	for i, p := range payouts {
+		if p.Player != g.Players[i] || want[i] != p.Coins {
+			t.Errorf("\nWant seat %d with %d\nGot  %+v", i, want[i], p)
+		}
+	}
+}
+
+func TestHandPayouts(t *testing.T) {
+	g := poker.NewVariantGame(alwaysTie)
+	g.SmallBlind = 1
+	g.BigBlind = 2
+	for _, name := range []string{"A", "B", "C"} {
+		p := poker.NewPlayer(name)
+		p.Coins = 100
+		g.Players = append(g.Players, p)
+	}
+	g.StartHand()
+
+	mustApply(t, g, poker.CALL, 2)
+	mustApply(t, g, poker.FOLD, 1)
+	for !g.HandIsOver() {
+		mustApply(t, g, poker.CHECK, 0)
+	}
+
+	// The pot is 5, seats 0 and 2 tie, and seat 2 is first to the left of the button
+	if len(g.Payouts) != 2 {
+		t.Fatalf("Wrong payouts: %+v", g.Payouts)
+	}
+	if g.Payouts[0].Player != g.Players[2] || g.Payouts[0].Coins != 3 || g.Payouts[1].Coins != 2 {
+		t.Errorf("Wrong payouts: %+v", g.Payouts)
+	}
+
+	want := []uint{100, 99, 101}
+	for i, p := range g.Players {
+		if want[i] != p.Coins {
+			t.Errorf("Seat %d\nWant %d\nGot  %d", i, want[i], p.Coins)
+		}
+	}
+}
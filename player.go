@@ -1,13 +1,24 @@
 package poker
 
 // Player stores all the player information.
+// BetCoins are the coins bet in the current round, and TotalBetCoins the coins bet in the whole hand.
+// HasShown is true when the player has shown its cards to everybody in the hand,
+// and Showdown decides if it shows or mucks them at the SHOWDOWN when it is allowed to muck.
 type Player struct {
-	Name       string
-	Hand       Cards
-	Coins      uint
-	BetCoins   uint
-	HasFolded  bool
-	HasChecked bool
+	Name          string
+	Hand          Cards
+	Coins         uint
+	BetCoins      uint
+	TotalBetCoins uint
+	HasFolded     bool
+	HasChecked    bool
+	HasActed      bool
+	HasShown      bool
+	Showdown      ShowdownPolicy
+
+	// reopenBet is the bet of the round which lets the player raise again after acting
+	// (the bet when it acted plus a full raise), or 0 if it hasn't acted in the round
+	reopenBet uint
 }
 
 // NewPlayer returns a player with that name.
@@ -0,0 +1,273 @@
+package poker
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	_ "embed"
+	"encoding/binary"
+	"errors"
+	"io"
+	"math/bits"
+	"sort"
+	"sync"
+)
+
+//go:generate go run gen_preflop.go
+
+var (
+	errWrongPreflopHands = errors.New("preflop equity needs two hands of two cards which don't share cards")
+	errWrongEquityTable  = errors.New("the preflop equity table is corrupted")
+	errUnknownHandClass  = errors.New("the hand class doesn't exist")
+)
+
+// preflopBoards is the number of boards of 5 cards with the 48 cards left after dealing two hands.
+const preflopBoards = 48 * 47 * 46 * 45 * 44 / (5 * 4 * 3 * 2)
+
+var preflopMagic = [4]byte{'P', 'K', 'E', 'Q'}
+
+// preflopEquities is the table written by WritePreflopEquities with gen_preflop.go.
+//
+//go:embed preflop_equities.gz
+var preflopEquities []byte
+
+var (
+	equityTable     map[uint32]uint32
+	equityTableErr  error
+	equityTableOnce sync.Once
+)
+
+// PreflopEquity returns the all-in equity of the hand against the opponent's hand before the flop
+// (the probability of winning at the showdown, counting ties as half), looking it up in the table
+// embedded in the package, so it is exact and it takes constant time.
+// Returns an error if the hands don't have two cards each, or if they share cards.
+func PreflopEquity(hand, opponent Cards) (float64, error) {
+	if hand.Count() != 2 || opponent.Count() != 2 || hand.CardsArePresent(opponent) {
+		return 0, errWrongPreflopHands
+	}
+
+	equityTableOnce.Do(func() {
+		equityTable, equityTableErr = readPreflopEquities(bytes.NewReader(preflopEquities))
+	})
+	if equityTableErr != nil {
+		return 0, equityTableErr
+	}
+
+	key, swapped := canonicalMatchup(hand, opponent)
+	value, ok := equityTable[key]
+	if !ok {
+		return 0, errWrongEquityTable
+	}
+
+	equity := float64(value) / (2 * preflopBoards)
+	if swapped {
+		return 1 - equity, nil
+	}
+
+	return equity, nil
+}
+
+// HandClassEquity returns the all-in equity of the hand class against the opponent's class before the flop:
+// the mean PreflopEquity of every pair of their hands which don't share cards.
+// The 169x169 table is computed the first time from the embedded one, then it takes constant time.
+// Returns an error if any of the classes doesn't exist.
+func HandClassEquity(hc, opponent HandClass) (float64, error) {
+	if hc < 0 || hc >= HAND_CLASSES || opponent < 0 || opponent >= HAND_CLASSES {
+		return 0, errUnknownHandClass
+	}
+
+	m, err := preflopMatchups()
+	if err != nil {
+		return 0, err
+	}
+
+	return m.equity[hc][opponent], nil
+}
+
+// ComputePreflopEquity computes the all-in equity of the hand against the opponent's hand before the flop,
+// going through every board. PreflopEquity returns the same, without computing it.
+// Returns an error if the hands don't have two cards each, or if they share cards.
+func ComputePreflopEquity(hand, opponent Cards) (float64, error) {
+	if hand.Count() != 2 || opponent.Count() != 2 || hand.CardsArePresent(opponent) {
+		return 0, errWrongPreflopHands
+	}
+
+	return float64(preflopShowdowns(hand, opponent)) / (2 * preflopBoards), nil
+}
+
+// preflopShowdowns returns twice the boards won by the hand plus the boards tied.
+func preflopShowdowns(hand, opponent Cards) uint32 {
+	deck := ALL_CARDS.QuitCards(hand | opponent).Split()
+
+	var won uint32
+	for a := 0; a < len(deck); a++ {
+		for b := a + 1; b < len(deck); b++ {
+			ab := deck[a] | deck[b]
+			for c := b + 1; c < len(deck); c++ {
+				abc := ab | deck[c]
+				for d := c + 1; d < len(deck); d++ {
+					abcd := abc | deck[d]
+					for e := d + 1; e < len(deck); e++ {
+						board := abcd | deck[e]
+						heroValue, villainValue := handValue(hand|board), handValue(opponent|board)
+						switch {
+						case heroValue > villainValue:
+							won += 2
+						case heroValue == villainValue:
+							won++
+						}
+					}
+				}
+			}
+		}
+	}
+
+	return won
+}
+
+// WritePreflopEquities computes the equity of every preflop matchup which is different when the suits are changed,
+// and writes them compressed with gzip, as they are embedded in the package (see gen_preflop.go).
+// There are many matchups, so it takes a long time.
+func WritePreflopEquities(w io.Writer) error {
+	keys := preflopMatchupKeys()
+
+	zw := gzip.NewWriter(w)
+	bw := bufio.NewWriter(zw)
+	if _, err := bw.Write(preflopMagic[:]); err != nil {
+		return err
+	}
+	if err := binary.Write(bw, binary.LittleEndian, uint32(len(keys))); err != nil {
+		return err
+	}
+
+	for _, key := range keys {
+		hand, opponent := matchupHands(key)
+		entry := [2]uint32{key, preflopShowdowns(hand, opponent)}
+		if err := binary.Write(bw, binary.LittleEndian, entry); err != nil {
+			return err
+		}
+	}
+
+	if err := bw.Flush(); err != nil {
+		return err
+	}
+
+	return zw.Close()
+}
+
+// readPreflopEquities reads the table written by WritePreflopEquities.
+func readPreflopEquities(r io.Reader) (map[uint32]uint32, error) {
+	zr, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, err
+	}
+	br := bufio.NewReader(zr)
+
+	var magic [4]byte
+	var n uint32
+	if _, err := io.ReadFull(br, magic[:]); err != nil {
+		return nil, err
+	}
+	if magic != preflopMagic {
+		return nil, errWrongEquityTable
+	}
+	if err := binary.Read(br, binary.LittleEndian, &n); err != nil {
+		return nil, err
+	}
+
+	table := make(map[uint32]uint32, n)
+	for i := uint32(0); i < n; i++ {
+		var entry [2]uint32
+		if err := binary.Read(br, binary.LittleEndian, &entry); err != nil {
+			return nil, err
+		}
+		table[entry[0]] = entry[1]
+	}
+
+	return table, nil
+}
+
+// preflopMatchupKeys returns the keys of every canonical matchup, sorted.
+func preflopMatchupKeys() []uint32 {
+	hands := FullRange().sortedHands()
+
+	unique := make(map[uint32]bool)
+	for _, hand := range hands {
+		for _, opponent := range hands {
+			if !hand.CardsArePresent(opponent) {
+				key, _ := canonicalMatchup(hand, opponent)
+				unique[key] = true
+			}
+		}
+	}
+
+	keys := make([]uint32, 0, len(unique))
+	for key := range unique {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+
+	return keys
+}
+
+// suitPermutations are the 24 ways of changing the suits of the cards.
+var suitPermutations = func() [][4]int {
+	perms := make([][4]int, 0, 24)
+	for a := 0; a < 4; a++ {
+		for b := 0; b < 4; b++ {
+			for c := 0; c < 4; c++ {
+				for d := 0; d < 4; d++ {
+					if a != b && a != c && a != d && b != c && b != d && c != d {
+						perms = append(perms, [4]int{a, b, c, d})
+					}
+				}
+			}
+		}
+	}
+
+	return perms
+}()
+
+// permuteSuits moves the cards of each suit i to the suit perm[i].
+func permuteSuits(cards Cards, perm [4]int) Cards {
+	var permuted Cards
+	for suit, to := range perm {
+		permuted |= (cards >> (13 * suit) & 0x1FFF) << (13 * to)
+	}
+
+	return permuted
+}
+
+// canonicalMatchup returns the key of the matchup, which is the same for every matchup with the same equity
+// changing the suits of the cards, or swapping the hands (then swapped is true, and the equity is the opponent's).
+func canonicalMatchup(hand, opponent Cards) (key uint32, swapped bool) {
+	first := true
+	var best [2]Cards
+	for _, perm := range suitPermutations {
+		a, b := permuteSuits(hand, perm), permuteSuits(opponent, perm)
+		for _, candidate := range [2][2]Cards{{a, b}, {b, a}} {
+			if first || candidate[0] < best[0] || candidate[0] == best[0] && candidate[1] < best[1] {
+				best, swapped, first = candidate, candidate[0] != a || candidate[1] != b, false
+			}
+		}
+	}
+
+	return handKey(best[0])<<12 | handKey(best[1]), swapped
+}
+
+// handKey returns the positions of the two cards of the hand, in 12 bits.
+func handKey(hand Cards) uint32 {
+	low := bits.TrailingZeros64(uint64(hand))
+	high := 63 - bits.LeadingZeros64(uint64(hand))
+
+	return uint32(high)<<6 | uint32(low)
+}
+
+// matchupHands returns the hands of the key of a matchup.
+func matchupHands(key uint32) (hand, opponent Cards) {
+	cards := func(k uint32) Cards {
+		return NO_CARD.SetBit(int(k>>6&0x3F)) | NO_CARD.SetBit(int(k&0x3F))
+	}
+
+	return cards(key >> 12), cards(key & 0xFFF)
+}
@@ -0,0 +1,97 @@
+package poker_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/arturo-source/poker-engine"
+)
+
+func TestPreflopEquityLookup(t *testing.T) {
+	c := poker.NewCard
+	tests := []struct {
+		hand, opponent poker.Cards
+	}{
+		{c("Ah") | c("As"), c("Kd") | c("Kc")},
+		{c("Kd") | c("Kc"), c("Ah") | c("As")},
+		{c("Ac") | c("Kc"), c("Qd") | c("Qh")},
+		{c("7s") | c("6s"), c("As") | c("Kd")},
+		{c("2c") | c("2d"), c("Jh") | c("Th")},
+	}
+
+	for _, tt := range tests {
+		want, err := poker.ComputePreflopEquity(tt.hand, tt.opponent)
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		got, err := poker.PreflopEquity(tt.hand, tt.opponent)
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+
+		if math.Abs(want-got) > 1e-12 {
+			t.Errorf("%s vs %s\nWant %f\nGot  %f", tt.hand, tt.opponent, want, got)
+		}
+	}
+}
+
+func TestPreflopEquityIsTheSameChangingSuits(t *testing.T) {
+	c := poker.NewCard
+	aces, err := poker.PreflopEquity(c("Ah")|c("As"), c("Kd")|c("Kc"))
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if math.Abs(aces-0.812555) > 1e-6 {
+		t.Errorf("\nWant %f\nGot  %f", 0.812555, aces)
+	}
+
+	same, _ := poker.PreflopEquity(c("Ac")|c("Ad"), c("Ks")|c("Kh"))
+	kings, _ := poker.PreflopEquity(c("Kd")|c("Kc"), c("Ah")|c("As"))
+	if same != aces || math.Abs(kings-(1-aces)) > 1e-12 {
+		t.Errorf("Wrong equities: %f %f %f", aces, same, kings)
+	}
+
+	sharedSuits, _ := poker.PreflopEquity(c("Ah")|c("As"), c("Kh")|c("Ks"))
+	if sharedSuits == aces {
+		t.Errorf("The equity should change when the hands share suits")
+	}
+}
+
+func TestPreflopEquityErrors(t *testing.T) {
+	c := poker.NewCard
+	if _, err := poker.PreflopEquity(c("Ah")|c("As"), c("Ah")|c("Kc")); err == nil {
+		t.Errorf("Wanted an error with hands which share cards. Got nil.")
+	}
+	if _, err := poker.PreflopEquity(c("Ah"), c("Kd")|c("Kc")); err == nil {
+		t.Errorf("Wanted an error with a hand of one card. Got nil.")
+	}
+	if _, err := poker.ComputePreflopEquity(c("Ah")|c("As")|c("Ad"), c("Kd")|c("Kc")); err == nil {
+		t.Errorf("Wanted an error with a hand of three cards. Got nil.")
+	}
+}
+
+func TestHandClassEquity(t *testing.T) {
+	aces, _ := poker.ParseHandClass("AA")
+	kings, _ := poker.ParseHandClass("KK")
+	suitedConnectors, _ := poker.ParseHandClass("76s")
+
+	equity, err := poker.HandClassEquity(aces, kings)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if equity < 0.81 || equity > 0.83 {
+		t.Errorf("AA vs KK\nWant about %f\nGot  %f", 0.82, equity)
+	}
+
+	for _, matchup := range [][2]poker.HandClass{{aces, kings}, {kings, suitedConnectors}, {suitedConnectors, suitedConnectors}} {
+		equity, _ := poker.HandClassEquity(matchup[0], matchup[1])
+		opponent, _ := poker.HandClassEquity(matchup[1], matchup[0])
+		if math.Abs(equity+opponent-1) > 1e-9 {
+			t.Errorf("%s vs %s: the equities %f and %f should add up to 1", matchup[0], matchup[1], equity, opponent)
+		}
+	}
+
+	if _, err := poker.HandClassEquity(aces, poker.HAND_CLASSES); err == nil {
+		t.Errorf("Wanted an error with a class which doesn't exist. Got nil.")
+	}
+}
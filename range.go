@@ -0,0 +1,54 @@
+package poker
+
+import "sort"
+
+// Range is a set of hands of two cards, each one with a weight (from 0 to 1),
+// which is how often the hand is played (or how likely it is).
+// A nil Range is used as a random hand (every hand with weight 1) where a Range is expected.
+type Range map[Cards]float64
+
+// FullRange returns every hand of two cards with weight 1.
+func FullRange() Range {
+	r := make(Range, MAX_CARDS*(MAX_CARDS-1)/2)
+	cards := ALL_CARDS.Split()
+	for i := range cards {
+		for j := i + 1; j < len(cards); j++ {
+			r[cards[i]|cards[j]] = 1
+		}
+	}
+
+	return r
+}
+
+// Combos returns the number of hands of the range, counting their weights.
+func (r Range) Combos() float64 {
+	var combos float64
+	for _, weight := range r {
+		combos += weight
+	}
+
+	return combos
+}
+
+// hands returns the hands of the range which don't have dead cards (and have some weight), and their weights.
+// They are sorted, so the order doesn't depend on the map.
+func (r Range) hands(dead Cards) ([]Cards, []float64) {
+	if r == nil {
+		r = FullRange()
+	}
+
+	hands := make([]Cards, 0, len(r))
+	for hand, weight := range r {
+		if weight > 0 && !hand.CardsArePresent(dead) {
+			hands = append(hands, hand)
+		}
+	}
+	sort.Slice(hands, func(i, j int) bool { return hands[i] < hands[j] })
+
+	weights := make([]float64, len(hands))
+	for i, hand := range hands {
+		weights[i] = r[hand]
+	}
+
+	return hands, weights
+}
@@ -0,0 +1,14 @@
+package poker_test
+
+import (
+	"testing"
+
+	"github.com/arturo-source/poker-engine"
+)
+
+func TestFullRangeCombos(t *testing.T) {
+	r := poker.FullRange()
+	if len(r) != 1326 || r.Combos() != 1326 {
+		t.Errorf("\nWant %d\nGot  %d (%f combos)", 1326, len(r), r.Combos())
+	}
+}
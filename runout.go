@@ -0,0 +1,69 @@
+package poker
+
+// Runout is one of the boards dealt by Board.RunOut, from the table cards already shown until the SHOWDOWN.
+// In Game.Runouts, Won has the coins won by each seat with this board.
+type Runout struct {
+	TableCards  []Cards
+	BurnedCards []Cards
+	Won         []uint
+}
+
+// RunOut deals the rest of the table cards that many times (at least once), each time with the next cards of the deck,
+// and returns every runout. The board is left in the SHOWDOWN with the first runout.
+// Returns an error if there are not enough cards in the deck.
+func (b *Board) RunOut(times int) ([]Runout, error) {
+	if times < 1 {
+		times = 1
+	}
+
+	runouts := make([]Runout, 0, times)
+	for i := 0; i < times; i++ {
+		run := Board{
+			deck:        b.deck,
+			rules:       b.rules,
+			TableCards:  append(make([]Cards, 0, MAX_CARDS_IN_BOARD), b.TableCards...),
+			BurnedCards: append(make([]Cards, 0, MAX_BURNED_CARDS), b.BurnedCards...),
+			State:       b.State,
+		}
+
+		for run.State != SHOWDOWN {
+			if err := run.NextBoardState(); err != nil {
+				return nil, err
+			}
+		}
+
+		runouts = append(runouts, Runout{TableCards: run.TableCards, BurnedCards: run.BurnedCards})
+	}
+
+	b.TableCards = append(b.TableCards[:0], runouts[0].TableCards...)
+	b.BurnedCards = append(b.BurnedCards[:0], runouts[0].BurnedCards...)
+	b.State = SHOWDOWN
+
+	return runouts, nil
+}
+
+// runOut deals the rest of the board RunItTimes times, when the betting is over
+// with two or more players all-in (or only one who isn't) and there are still table cards to deal.
+// Each runout is added to History as a RUNOUT.
+// Returns false if the board has to be dealt only once.
+func (g *Game) runOut() (bool, error) {
+	cardsLeft := int(g.Board.State) < len(g.Board.variant().TableCards)
+	if g.RunItTimes < 2 || !cardsLeft || g.playersInHand() < 2 || g.playersAbleToAct() >= 2 {
+		return false, nil
+	}
+
+	state := g.Board.State
+
+	runouts, err := g.Board.RunOut(g.RunItTimes)
+	if err != nil {
+		return false, err
+	}
+
+	for i := range runouts {
+		runouts[i].Won = make([]uint, len(g.Players))
+		g.History = append(g.History, Action{Seat: NO_TURN, Kind: RUNOUT, Amount: uint(i + 1), State: state})
+	}
+	g.Runouts = runouts
+
+	return true, nil
+}
@@ -0,0 +1,156 @@
+package poker_test
+
+import (
+	"testing"
+
+	"github.com/arturo-source/poker-engine"
+)
+
+func TestBoardRunOutTwice(t *testing.T) {
+	d := poker.NewDeck()
+	b := poker.NewBoard(d)
+	b.NextBoardState()
+
+	runouts, err := b.RunOut(2)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if len(runouts) != 2 || b.State != poker.SHOWDOWN {
+		t.Fatalf("Wrong runouts %v in state %d", runouts, b.State)
+	}
+
+	flop := poker.JoinCards(runouts[0].TableCards[:3]...)
+	var seen poker.Cards
+	for _, runout := range runouts {
+		if len(runout.TableCards) != 5 || len(runout.BurnedCards) != 3 {
+			t.Fatalf("Wrong runout: %v", runout)
+		}
+		if poker.JoinCards(runout.TableCards[:3]...) != flop {
+			t.Errorf("Every runout should keep the flop")
+		}
+
+		// turn and river cards (and their burned cards) are different in each runout
+		dealt := poker.JoinCards(runout.TableCards[3:]...) | poker.JoinCards(runout.BurnedCards[1:]...)
+		if seen.CardsArePresent(dealt) {
+			t.Errorf("Cards dealt twice: %s", seen&dealt)
+		}
+		seen |= dealt
+	}
+
+	if poker.JoinCards(b.TableCards...) != poker.JoinCards(runouts[0].TableCards...) {
+		t.Errorf("The board should have the first runout")
+	}
+}
+
+func TestRunItTwiceSplitsThePot(t *testing.T) {
+	g := newBettingGame(100, 100, 100)
+	g.Ante = 1
+	g.RunItTimes = 2
+	g.StartHand()
+
+	mustApply(t, g, poker.FOLD, 0)
+	mustApply(t, g, poker.RAISE, 99)
+	mustApply(t, g, poker.CALL, 99)
+
+	if !g.HandIsOver() || len(g.Runouts) != 2 {
+		t.Fatalf("The board should have been run twice: %v", g.Runouts)
+	}
+
+	// The pot is 201, the odd coin goes to the first runout
+	for i, want := range []uint{101, 100} {
+		var won uint
+		for _, coins := range g.Runouts[i].Won {
+			won += coins
+		}
+		if want != won {
+			t.Errorf("Runout %d\nWant %d\nGot  %d", i, want, won)
+		}
+	}
+
+	for seat, p := range g.Players {
+		won := g.Runouts[0].Won[seat] + g.Runouts[1].Won[seat]
+		if seat != 0 && p.Coins != won {
+			t.Errorf("Seat %d\nWant %d\nGot  %d", seat, won, p.Coins)
+		}
+	}
+	if totalCoins(g) != 300 {
+		t.Errorf("Coins are not conserved: %d", totalCoins(g))
+	}
+}
+
+func TestRunItThreeTimesOnTheTurn(t *testing.T) {
+	g := newBettingGame(100, 100)
+	g.RunItTimes = 3
+	g.StartHand()
+
+	mustApply(t, g, poker.CALL, 2)
+	mustApply(t, g, poker.CHECK, 2)
+	mustApply(t, g, poker.CHECK, 0)
+	mustApply(t, g, poker.CHECK, 0)
+	mustApply(t, g, poker.BET, 98)
+	mustApply(t, g, poker.CALL, 98)
+
+	if len(g.Runouts) != 3 {
+		t.Fatalf("\nWant %d runouts\nGot  %d", 3, len(g.Runouts))
+	}
+
+	// Every runout is in the history after the call
+	runouts := g.History[6:9]
+	for i, a := range runouts {
+		want := poker.Action{Seat: poker.NO_TURN, Kind: poker.RUNOUT, Amount: uint(i + 1), State: poker.TURN}
+		if want != a {
+			t.Errorf("\nWant %v\nGot  %v", want, a)
+		}
+	}
+	for _, runout := range g.Runouts {
+		if poker.JoinCards(runout.TableCards[:4]...) != poker.JoinCards(g.Runouts[0].TableCards[:4]...) {
+			t.Errorf("Every runout should keep the turn")
+		}
+	}
+	if totalCoins(g) != 200 {
+		t.Errorf("Coins are not conserved: %d", totalCoins(g))
+	}
+}
+
+func TestRunItTwiceOnlyWhenAllIn(t *testing.T) {
+	g := newBettingGame(100, 100)
+	g.RunItTimes = 2
+	g.StartHand()
+
+	mustApply(t, g, poker.CALL, 2)
+	for !g.HandIsOver() {
+		mustApply(t, g, poker.CHECK, 0)
+	}
+
+	if g.Runouts != nil {
+		t.Errorf("The board shouldn't be run twice: %v", g.Runouts)
+	}
+}
+
+func TestRunItTwiceOnTheRiverDealsOnce(t *testing.T) {
+	g := newBettingGame(100, 100)
+	g.RunItTimes = 2
+	g.StartHand()
+
+	mustApply(t, g, poker.CALL, 2)
+	for g.Board.State != poker.RIVER {
+		mustApply(t, g, poker.CHECK, 0)
+	}
+	mustApply(t, g, poker.BET, 98)
+	mustApply(t, g, poker.CALL, 98)
+
+	if !g.HandIsOver() {
+		t.Fatalf("Hand should be over")
+	}
+	if g.Runouts != nil {
+		t.Errorf("There are no cards left to deal, so the board shouldn't be run twice: %v", g.Runouts)
+	}
+	for _, a := range g.History {
+		if a.Kind == poker.RUNOUT {
+			t.Errorf("There shouldn't be runouts in the history: %v", a)
+		}
+	}
+	if totalCoins(g) != 200 {
+		t.Errorf("Coins are not conserved: %d", totalCoins(g))
+	}
+}
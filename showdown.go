@@ -0,0 +1,182 @@
+package poker
+
+import "errors"
+
+var (
+	errHandIsNotOver = errors.New("the hand is not over yet")
+	errNoHandToShow  = errors.New("the player has no cards to show")
+)
+
+// ShowdownPolicy is what a player does with its cards at the SHOWDOWN when it is allowed to muck them:
+// when, in every pot it plays against other players, somebody has already shown (so the first player always shows),
+// and not everybody is all-in. A player who mucks gives up the pots, even if its hand would win them.
+type ShowdownPolicy int
+
+const (
+	// MUCK_WHEN_BEATEN mucks if a hand already shown beats it in every pot it plays, and shows otherwise
+	MUCK_WHEN_BEATEN ShowdownPolicy = iota
+	// ALWAYS_SHOW shows the cards, even if they are beaten
+	ALWAYS_SHOW
+	// ALWAYS_MUCK mucks the cards whenever it is allowed to
+	ALWAYS_MUCK
+)
+
+func (sp ShowdownPolicy) String() string {
+	names := [...]string{"Muck when beaten", "Always show", "Always muck"}
+
+	if sp < MUCK_WHEN_BEATEN || sp > ALWAYS_MUCK {
+		return "Unknown ShowdownPolicy"
+	}
+
+	return names[sp]
+}
+
+// showdown decides who shows the cards at the SHOWDOWN, going around the table in the showdownOrder.
+// Each player shows or mucks as its Showdown policy says, if it is allowed to muck, and shows otherwise.
+// When the players are all-in (at most one of them has coins), everybody shows.
+// Each decision is added to History as a SHOW or a MUCK.
+func (g *Game) showdown() {
+	order := g.showdownOrder()
+	allIn := 0
+	for _, p := range order {
+		if p.Coins == 0 {
+			allIn++
+		}
+	}
+	everybodyShows := allIn >= len(order)-1
+
+	tableCards := JoinCards(g.Board.TableCards...)
+	pots := g.Pots()
+	for _, p := range order {
+		kind := SHOW
+		if !everybodyShows && g.canMuck(p, pots) {
+			switch p.Showdown {
+			case MUCK_WHEN_BEATEN:
+				if g.isBeatenInEveryPot(p, pots, tableCards) {
+					kind = MUCK
+				}
+			case ALWAYS_MUCK:
+				kind = MUCK
+			}
+		}
+
+		p.HasShown = kind == SHOW
+		g.History = append(g.History, Action{Seat: g.Seat(p), Kind: kind, Amount: p.BetCoins, State: SHOWDOWN})
+	}
+}
+
+// showdownOrder returns the players in the hand in the order they show the cards:
+// first the last player who bet or raised in the last betting round, or the first to the left of the button
+// if nobody did, and then the next ones going around the table.
+func (g *Game) showdownOrder() []*Player {
+	first := g.nextSeat(g.Button, isInHand)
+
+	for i := len(g.History) - 1; i >= 0; i-- {
+		a := g.History[i]
+		if a.State != g.History[len(g.History)-1].State {
+			break
+		}
+		if (a.Kind == BET || a.Kind == RAISE) && isInHand(g.Players[a.Seat]) {
+			first = a.Seat
+			break
+		}
+	}
+
+	order := make([]*Player, 0, len(g.Players))
+	for i := 0; i < len(g.Players); i++ {
+		if p := g.Players[(first+i)%len(g.Players)]; isInHand(p) {
+			order = append(order, p)
+		}
+	}
+
+	return order
+}
+
+// canMuck returns true if, in every pot the player plays against other players, somebody has already shown,
+// so the pots are never left without a shown hand.
+func (g *Game) canMuck(p *Player, pots []SidePot) bool {
+	for _, pot := range pots {
+		plays, shown := false, false
+		for _, other := range pot.Players {
+			plays = plays || other == p
+			shown = shown || (other != p && other.HasShown)
+		}
+		if plays && len(pot.Players) >= 2 && !shown {
+			return false
+		}
+	}
+
+	return true
+}
+
+// contenders returns the players of the pot who can win it: after a SHOWDOWN, only the ones who have shown
+// (if anybody has, a player alone in a pot wins it even if it mucks).
+func (g *Game) contenders(pot SidePot, showdown bool) []*Player {
+	if !showdown {
+		return pot.Players
+	}
+
+	shown := make([]*Player, 0, len(pot.Players))
+	for _, p := range pot.Players {
+		if p.HasShown {
+			shown = append(shown, p)
+		}
+	}
+	if len(shown) == 0 {
+		return pot.Players
+	}
+
+	return shown
+}
+
+// isBeatenInEveryPot returns true if, in every pot the player plays, a player who has already shown has a better hand.
+func (g *Game) isBeatenInEveryPot(p *Player, pots []SidePot, tableCards Cards) bool {
+	for _, pot := range pots {
+		plays := false
+		shown := make([]*Player, 0, len(pot.Players))
+		for _, other := range pot.Players {
+			switch {
+			case other == p:
+				plays = true
+			case other.HasShown:
+				shown = append(shown, other)
+			}
+		}
+		if !plays {
+			continue
+		}
+		if len(shown) == 0 {
+			return false
+		}
+
+		for _, winner := range g.Board.variant().winners(tableCards, append(shown, p)) {
+			if winner == p {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// Show reveals the hand of the player once the hand is over, even if it has mucked or folded,
+// or if it has won without a SHOWDOWN. It is added to History as a SHOW.
+// Returns an error if the hand is not over, or if the player has no cards.
+func (g *Game) Show(p *Player) error {
+	if !g.handOver {
+		return errHandIsNotOver
+	}
+
+	seat := g.Seat(p)
+	if seat == SPECTATOR || p.Hand == NO_CARD {
+		return errNoHandToShow
+	}
+	if p.HasShown {
+		return nil
+	}
+
+	p.HasShown = true
+	g.History = append(g.History, Action{Seat: seat, Kind: SHOW, Amount: p.BetCoins, State: g.Board.State})
+
+	return nil
+}
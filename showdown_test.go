@@ -0,0 +1,155 @@
+package poker_test
+
+import (
+	"testing"
+
+	"github.com/arturo-source/poker-engine"
+)
+
+func newKuhnShowdownGame(t *testing.T) *poker.Game {
+	p1 := poker.NewPlayer("P1")
+	p2 := poker.NewPlayer("P2")
+	p1.Coins, p2.Coins = 10, 10
+	g := poker.NewKuhnGame(p1, p2)
+
+	if err := g.StartHand(); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	return g
+}
+
+// showdownActions returns the SHOW and MUCK actions of the history.
+func showdownActions(g *poker.Game) []poker.Action {
+	actions := make([]poker.Action, 0)
+	for _, a := range g.History {
+		if a.Kind == poker.SHOW || a.Kind == poker.MUCK {
+			actions = append(actions, a)
+		}
+	}
+
+	return actions
+}
+
+func TestShowdownWithoutBetsStartsLeftOfButton(t *testing.T) {
+	g := newKuhnShowdownGame(t)
+	mustApply(t, g, poker.CHECK, 0)
+	mustApply(t, g, poker.CHECK, 0)
+
+	first := (g.Button + 1) % 2
+	second := g.Button
+	actions := showdownActions(g)
+	if len(actions) != 2 || actions[0].Seat != first || actions[0].Kind != poker.SHOW || actions[1].Seat != second {
+		t.Fatalf("Wrong showdown: %v", actions)
+	}
+
+	// Kuhn cards are all spades, so the highest card has the highest bits
+	wantKind := poker.MUCK
+	if g.Players[second].Hand > g.Players[first].Hand {
+		wantKind = poker.SHOW
+	}
+	if actions[1].Kind != wantKind || g.Players[second].HasShown != (wantKind == poker.SHOW) {
+		t.Errorf("\nWant %s\nGot  %s", wantKind, actions[1].Kind)
+	}
+
+	view := g.ViewFor(g.Players[first])
+	if hidden := view.Players[second].Hand == poker.NO_CARD; hidden != (wantKind == poker.MUCK) {
+		t.Errorf("Mucked hands should be hidden, and shown hands public: %v", view.Players[second])
+	}
+}
+
+func TestShowdownPolicies(t *testing.T) {
+	// Mucking gives up the pot, even with the best hand, but the first player has to show
+	g := newKuhnShowdownGame(t)
+	for _, p := range g.Players {
+		p.Showdown = poker.ALWAYS_MUCK
+	}
+	mustApply(t, g, poker.CHECK, 0)
+	mustApply(t, g, poker.CHECK, 0)
+
+	first, second := g.Players[(g.Button+1)%2], g.Players[g.Button]
+	actions := showdownActions(g)
+	if len(actions) != 2 || actions[0].Kind != poker.SHOW || actions[1].Kind != poker.MUCK {
+		t.Fatalf("Wrong showdown: %v", actions)
+	}
+	if first.Coins != 11 || second.Coins != 9 {
+		t.Errorf("The player who has shown should win the pot: %d %d", first.Coins, second.Coins)
+	}
+
+	// Showing even when beaten
+	g = newKuhnShowdownGame(t)
+	for _, p := range g.Players {
+		p.Showdown = poker.ALWAYS_SHOW
+	}
+	mustApply(t, g, poker.CHECK, 0)
+	mustApply(t, g, poker.CHECK, 0)
+
+	actions = showdownActions(g)
+	if len(actions) != 2 || actions[0].Kind != poker.SHOW || actions[1].Kind != poker.SHOW {
+		t.Fatalf("Wrong showdown: %v", actions)
+	}
+	winner := g.Players[0]
+	if g.Players[1].Hand > winner.Hand {
+		winner = g.Players[1]
+	}
+	if winner.Coins != 11 {
+		t.Errorf("The best hand should win the pot: %d", winner.Coins)
+	}
+}
+
+func TestLastAggressorShowsFirst(t *testing.T) {
+	g := newKuhnShowdownGame(t)
+	mustApply(t, g, poker.CHECK, 0)
+	aggressor := g.Turn
+	mustApply(t, g, poker.BET, 1)
+	mustApply(t, g, poker.CALL, 1)
+
+	actions := showdownActions(g)
+	if len(actions) != 2 || actions[0].Seat != aggressor || actions[0].Kind != poker.SHOW {
+		t.Errorf("The last aggressor should show first: %v", actions)
+	}
+}
+
+func TestShowAfterWinningWithoutShowdown(t *testing.T) {
+	g := newKuhnShowdownGame(t)
+	bettor := g.Players[g.Turn]
+	if err := g.Show(bettor); err == nil {
+		t.Errorf("Wanted an error showing before the hand is over. Got nil.")
+	}
+
+	mustApply(t, g, poker.BET, 1)
+	folder := g.Players[g.Turn]
+	mustApply(t, g, poker.FOLD, 0)
+
+	if len(showdownActions(g)) != 0 || bettor.HasShown {
+		t.Fatalf("Nobody should show without a showdown: %v", g.History)
+	}
+	if view := g.ViewFor(folder); view.Players[g.Seat(bettor)].Hand != poker.NO_CARD {
+		t.Errorf("The hand should be hidden")
+	}
+
+	if err := g.Show(bettor); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if view := g.ViewFor(folder); view.Players[g.Seat(bettor)].Hand != bettor.Hand {
+		t.Errorf("\nWant %s\nGot  %s", bettor.Hand, view.Players[g.Seat(bettor)].Hand)
+	}
+	last := g.History[len(g.History)-1]
+	if last.Kind != poker.SHOW || last.Seat != g.Seat(bettor) {
+		t.Errorf("The show should be in the history: %v", last)
+	}
+}
+
+func TestEverybodyShowsWhenAllIn(t *testing.T) {
+	g := newBettingGame(100, 100)
+	g.StartHand()
+
+	mustApply(t, g, poker.RAISE, 100)
+	mustApply(t, g, poker.CALL, 100)
+
+	for i, p := range g.Players {
+		if !p.HasShown {
+			t.Errorf("Seat %d should have shown", i)
+		}
+	}
+}
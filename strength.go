@@ -0,0 +1,182 @@
+package poker
+
+import (
+	"errors"
+	"math/rand"
+	"sort"
+)
+
+var (
+	errWrongBoardForStrength = errors.New("hand strength needs 3, 4 or 5 table cards (or none if sampled)")
+	errEmptyRange            = errors.New("no hands in the range without the known cards")
+)
+
+const (
+	ahead = iota
+	tied
+	behind
+)
+
+// HandStrength measures how good a hand is against one opponent.
+//
+// HS is the probability of being ahead now (ties count as half).
+// PPot is the probability of being ahead at the showdown when it is behind now (positive potential),
+// and NPot the probability of falling behind when it is ahead now (negative potential).
+// EHS is the effective hand strength, HS*(1-NPot) + (1-HS)*PPot.
+// Equity is the probability of being ahead at the showdown (ties count as half), which is HS in the RIVER.
+// There is no potential when all the table cards are shown.
+type HandStrength struct {
+	HS     float64
+	PPot   float64
+	NPot   float64
+	EHS    float64
+	Equity float64
+}
+
+// handPotential accumulates the outcomes of the hand, now and at the showdown, as in
+// "Opponent Modeling in Poker" (Billings et al.).
+type handPotential struct {
+	now   [3]float64
+	hp    [3][3]float64
+	total [3]float64
+}
+
+// add adds an outcome with that weight, current is the outcome now, and final at the showdown.
+func (hp *handPotential) add(current, final int, weight float64) {
+	hp.hp[current][final] += weight
+	hp.total[current] += weight
+}
+
+func (hp *handPotential) strength() HandStrength {
+	hs := HandStrength{}
+	if sum := hp.now[ahead] + hp.now[tied] + hp.now[behind]; sum > 0 {
+		hs.HS = (hp.now[ahead] + hp.now[tied]/2) / sum
+	}
+	if den := hp.total[behind] + hp.total[tied]/2; den > 0 {
+		hs.PPot = (hp.hp[behind][ahead] + hp.hp[behind][tied]/2 + hp.hp[tied][ahead]/2) / den
+	}
+	if den := hp.total[ahead] + hp.total[tied]/2; den > 0 {
+		hs.NPot = (hp.hp[ahead][behind] + hp.hp[tied][behind]/2 + hp.hp[ahead][tied]/2) / den
+	}
+	hs.EHS = hs.HS*(1-hs.NPot) + (1-hs.HS)*hs.PPot
+
+	hs.Equity = hs.HS
+	var won, total float64
+	for current := range hp.hp {
+		won += hp.hp[current][ahead] + hp.hp[current][tied]/2
+		total += hp.total[current]
+	}
+	if total > 0 {
+		hs.Equity = won / total
+	}
+
+	return hs
+}
+
+// ComputeHandStrength returns the exact HandStrength of the hand, with 3, 4 or 5 table cards,
+// against an opponent with a hand of the range (nil is a random hand),
+// enumerating every hand of the opponent and every card left to show.
+// In the FLOP, that's around a million showdowns, so SampleHandStrength may be preferred.
+func ComputeHandStrength(hand Cards, tableCards []Cards, opponent Range) (HandStrength, error) {
+	board := JoinCards(tableCards...)
+	if board.Count() < 3 || board.Count() > MAX_CARDS_IN_BOARD {
+		return HandStrength{}, errWrongBoardForStrength
+	}
+
+	hands, weights := opponent.hands(hand | board)
+	if len(hands) == 0 {
+		return HandStrength{}, errEmptyRange
+	}
+
+	hp := handPotential{}
+	for i, oppHand := range hands {
+		current := compareHands(hand, oppHand, board)
+		hp.now[current] += weights[i]
+		if board.Count() == MAX_CARDS_IN_BOARD {
+			continue
+		}
+
+		runouts := ALL_CARDS.QuitCards(hand | oppHand | board).Split()
+		for j, turn := range runouts {
+			if board.Count() == 4 {
+				hp.add(current, compareHands(hand, oppHand, board|turn), weights[i])
+				continue
+			}
+
+			for _, river := range runouts[j+1:] {
+				hp.add(current, compareHands(hand, oppHand, board|turn|river), weights[i])
+			}
+		}
+	}
+
+	return hp.strength(), nil
+}
+
+// SampleHandStrength estimates the HandStrength of the hand with that number of samples,
+// each one a hand of the opponent (chosen by its weight in the range, nil is a random hand)
+// and the cards left to show. It also works in the PREFLOP (without table cards).
+// If r is nil, the global random generator is used.
+func SampleHandStrength(hand Cards, tableCards []Cards, opponent Range, samples int, r *rand.Rand) (HandStrength, error) {
+	board := JoinCards(tableCards...)
+	if board.Count() == 1 || board.Count() == 2 || board.Count() > MAX_CARDS_IN_BOARD {
+		return HandStrength{}, errWrongBoardForStrength
+	}
+
+	hands, weights := opponent.hands(hand | board)
+	if len(hands) == 0 {
+		return HandStrength{}, errEmptyRange
+	}
+
+	random, intn := rand.Float64, rand.Intn
+	if r != nil {
+		random, intn = r.Float64, r.Intn
+	}
+
+	cumulative := make([]float64, len(weights))
+	var sum float64
+	for i, weight := range weights {
+		sum += weight
+		cumulative[i] = sum
+	}
+
+	hp := handPotential{}
+	for n := 0; n < samples; n++ {
+		x := random() * sum
+		i := sort.Search(len(cumulative)-1, func(i int) bool { return cumulative[i] > x })
+		oppHand := hands[i]
+
+		current := compareHands(hand, oppHand, board)
+		hp.now[current]++
+		if board.Count() == MAX_CARDS_IN_BOARD {
+			continue
+		}
+
+		final := board
+		deck := ALL_CARDS.QuitCards(hand | oppHand | board).Split()
+		for k := board.Count(); k < MAX_CARDS_IN_BOARD; k++ {
+			j := intn(len(deck))
+			final |= deck[j]
+			deck[j] = deck[len(deck)-1]
+			deck = deck[:len(deck)-1]
+		}
+		hp.add(current, compareHands(hand, oppHand, final), 1)
+	}
+
+	return hp.strength(), nil
+}
+
+// compareHands returns whether the hand is ahead, tied or behind the opponent's hand with the table cards.
+func compareHands(hand, opponent, tableCards Cards) int {
+	hero, villain := &Player{Hand: hand}, &Player{Hand: opponent}
+	heroBest, heroKind := BestHand(hero, tableCards)
+	villainBest, villainKind := BestHand(villain, tableCards)
+
+	switch compareHandValues(PlayerHandValue{hero, heroBest, heroKind}, PlayerHandValue{villain, villainBest, villainKind}, tableCards) {
+	case hero:
+		return ahead
+	case villain:
+		return behind
+	default:
+		return tied
+	}
+}
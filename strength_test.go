@@ -0,0 +1,76 @@
+package poker_test
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/arturo-source/poker-engine"
+)
+
+func TestHandStrengthOfTheNuts(t *testing.T) {
+	c := poker.NewCard
+	hs, err := poker.ComputeHandStrength(c("Jh")|c("Th"), []poker.Cards{c("Ah"), c("Kh"), c("Qh"), c("7c"), c("2d")}, nil)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	want := poker.HandStrength{HS: 1, EHS: 1, Equity: 1}
+	if want != hs {
+		t.Errorf("\nWant %+v\nGot  %+v", want, hs)
+	}
+}
+
+func TestNegativePotentialAgainstARange(t *testing.T) {
+	c := poker.NewCard
+	kings := poker.Range{c("Kh") | c("Kd"): 1}
+	hs, err := poker.ComputeHandStrength(c("Ah")|c("Ad"), []poker.Cards{c("2c"), c("7s"), c("9d")}, kings)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	// Kings need one of the two kings left in 45 cards, without an ace (or both kings)
+	wantNPot := (2*41 + 1) / 990.0
+	if hs.HS != 1 || hs.PPot != 0 || math.Abs(hs.NPot-wantNPot) > 1e-9 {
+		t.Errorf("Wrong hand strength: %+v, NPot should be %f", hs, wantNPot)
+	}
+	if math.Abs(hs.EHS-(1-wantNPot)) > 1e-9 || math.Abs(hs.Equity-(1-wantNPot)) > 1e-9 {
+		t.Errorf("\nWant %f\nGot  %+v", 1-wantNPot, hs)
+	}
+}
+
+func TestSampledHandStrengthIsCloseToExact(t *testing.T) {
+	c := poker.NewCard
+	hand := c("Ah") | c("5h")
+	tableCards := []poker.Cards{c("Kh"), c("8h"), c("4c"), c("Qd")}
+
+	exact, err := poker.ComputeHandStrength(hand, tableCards, nil)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	sampled, err := poker.SampleHandStrength(hand, tableCards, nil, 20000, rand.New(rand.NewSource(1)))
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	if math.Abs(exact.HS-sampled.HS) > 0.02 || math.Abs(exact.EHS-sampled.EHS) > 0.02 || math.Abs(exact.PPot-sampled.PPot) > 0.03 {
+		t.Errorf("\nExact   %+v\nSampled %+v", exact, sampled)
+	}
+}
+
+func TestHandStrengthErrors(t *testing.T) {
+	c := poker.NewCard
+	if _, err := poker.ComputeHandStrength(c("Ah")|c("Ad"), nil, nil); err == nil {
+		t.Errorf("Exact hand strength shouldn't work in the PREFLOP")
+	}
+	if hs, err := poker.SampleHandStrength(c("Ah")|c("Ad"), nil, nil, 5000, rand.New(rand.NewSource(1))); err != nil {
+		t.Errorf("Unexpected error: %s", err)
+	} else if math.Abs(hs.Equity-0.85) > 0.02 {
+		t.Errorf("Aces should win 85%% of the times against a random hand: %+v", hs)
+	}
+
+	blocked := poker.Range{c("Ah") | c("Kd"): 1}
+	if _, err := poker.ComputeHandStrength(c("Ah")|c("Ad"), []poker.Cards{c("2c"), c("7s"), c("9d")}, blocked); err == nil {
+		t.Errorf("A range without possible hands should return an error")
+	}
+}
@@ -0,0 +1,64 @@
+package poker
+
+// BettingStructure decides how many coins a player can bet or raise in a Game.
+//
+// RaiseLimits returns the minimum and the maximum coins the player can have bet in the round after betting or raising,
+// and false if it can't. Game only asks it when the player has coins to raise and someone else can still act.
+type BettingStructure interface {
+	RaiseLimits(g *Game, p *Player) (min, max uint, ok bool)
+}
+
+// NoLimit lets players bet all their coins, raising at least as much as the biggest bet or raise of the round
+// (or the big blind). An all-in for less than that doesn't change the minimum raise.
+type NoLimit struct{}
+
+// RaiseLimits implements BettingStructure.
+func (NoLimit) RaiseLimits(g *Game, p *Player) (min, max uint, ok bool) {
+	max = p.BetCoins + p.Coins
+	min = minUint(g.CurrentBet()+g.LastRaise(), max)
+
+	return min, max, true
+}
+
+// PotLimit lets players bet or raise up to the size of the pot, counting the bets of the round
+// and the coins to call first (so the maximum raise is to three times the bet, plus the pot before it).
+// The minimum raise is the same as in NoLimit.
+type PotLimit struct{}
+
+// RaiseLimits implements BettingStructure.
+func (PotLimit) RaiseLimits(g *Game, p *Player) (min, max uint, ok bool) {
+	currentBet := g.CurrentBet()
+	potAfterCall := g.TotalPot() + currentBet - p.BetCoins
+
+	max = minUint(currentBet+potAfterCall, p.BetCoins+p.Coins)
+	min = minUint(currentBet+g.LastRaise(), max)
+
+	return min, max, true
+}
+
+// FixedLimit only lets players bet or raise SmallBet until BigBetState, and BigBet from then on,
+// and at most MaxRaises times per round (bets, raises and the big blind count), if MaxRaises is not 0.
+// If UncappedHeadsUp is true, there is no maximum when only two players are left in the hand.
+type FixedLimit struct {
+	SmallBet        uint
+	BigBet          uint
+	BigBetState     BoardState
+	MaxRaises       int
+	UncappedHeadsUp bool
+}
+
+// RaiseLimits implements BettingStructure.
+func (fl FixedLimit) RaiseLimits(g *Game, p *Player) (min, max uint, ok bool) {
+	capped := fl.MaxRaises > 0 && !(fl.UncappedHeadsUp && g.playersInHand() == 2)
+	if capped && g.Raises() >= fl.MaxRaises {
+		return 0, 0, false
+	}
+
+	bet := fl.SmallBet
+	if g.Board.State >= fl.BigBetState {
+		bet = fl.BigBet
+	}
+
+	amount := minUint(g.CurrentBet()+bet, p.BetCoins+p.Coins)
+	return amount, amount, true
+}
@@ -0,0 +1,106 @@
+package poker_test
+
+import (
+	"testing"
+
+	"github.com/arturo-source/poker-engine"
+)
+
+// raiseAmounts returns the minimum and the maximum Amount of the BET or RAISE legal actions, and false if there are none.
+func raiseAmounts(g *poker.Game) (min, max uint, ok bool) {
+	for _, a := range g.LegalActions() {
+		if a.Kind == poker.BET || a.Kind == poker.RAISE {
+			if !ok {
+				min, ok = a.Amount, true
+			}
+			max = a.Amount
+		}
+	}
+
+	return min, max, ok
+}
+
+func checkRaiseAmounts(t *testing.T, g *poker.Game, wantMin, wantMax uint) {
+	t.Helper()
+
+	min, max, ok := raiseAmounts(g)
+	if !ok {
+		t.Fatalf("Raising should be legal")
+	}
+	if wantMin != min || wantMax != max {
+		t.Errorf("\nWant %d-%d\nGot  %d-%d", wantMin, wantMax, min, max)
+	}
+}
+
+func TestNoLimitMinimumRaise(t *testing.T) {
+	g := newBettingGame(1000, 1000, 1000)
+	g.StartHand()
+
+	checkRaiseAmounts(t, g, 4, 1000)
+	mustApply(t, g, poker.RAISE, 10)
+
+	// Raised 8, so the next raise must be to 18 at least
+	checkRaiseAmounts(t, g, 18, 1000)
+}
+
+func TestPotLimitRaises(t *testing.T) {
+	g := newBettingGame(1000, 1000)
+	g.Structure = poker.PotLimit{}
+	g.StartHand()
+
+	// Small blind calls 1, and the pot is 4
+	checkRaiseAmounts(t, g, 4, 6)
+	mustApply(t, g, poker.RAISE, 6)
+
+	// Big blind calls 4, and the pot is 12
+	checkRaiseAmounts(t, g, 10, 18)
+	mustApply(t, g, poker.CALL, 6)
+
+	// Nobody has bet in the FLOP, so the bet is the pot
+	checkRaiseAmounts(t, g, 2, 12)
+}
+
+func TestPotLimitShortStack(t *testing.T) {
+	g := newBettingGame(5, 1000)
+	g.Structure = poker.PotLimit{}
+	g.StartHand()
+
+	checkRaiseAmounts(t, g, 4, 5)
+}
+
+func TestFixedLimitCap(t *testing.T) {
+	g := newBettingGame(1000, 1000, 1000)
+	g.Structure = poker.FixedLimit{SmallBet: 2, BigBet: 4, BigBetState: poker.FLOP, MaxRaises: 4, UncappedHeadsUp: true}
+	g.StartHand()
+
+	checkRaiseAmounts(t, g, 4, 4)
+	mustApply(t, g, poker.RAISE, 4)
+	mustApply(t, g, poker.RAISE, 6)
+	mustApply(t, g, poker.RAISE, 8)
+
+	// Capped, with three players in the hand
+	if _, _, ok := raiseAmounts(g); ok {
+		t.Errorf("Raising should be capped: %v", g.LegalActions())
+	}
+	mustApply(t, g, poker.CALL, 8)
+	mustApply(t, g, poker.CALL, 8)
+
+	checkRaiseAmounts(t, g, 4, 4)
+}
+
+func TestFixedLimitUncappedHeadsUp(t *testing.T) {
+	for _, uncapped := range []bool{false, true} {
+		g := newBettingGame(1000, 1000)
+		g.Structure = poker.FixedLimit{SmallBet: 2, BigBet: 4, BigBetState: poker.FLOP, MaxRaises: 4, UncappedHeadsUp: uncapped}
+		g.StartHand()
+
+		for amount := uint(4); amount <= 8; amount += 2 {
+			mustApply(t, g, poker.RAISE, amount)
+		}
+
+		_, _, ok := raiseAmounts(g)
+		if uncapped != ok {
+			t.Errorf("Uncapped %t, but raising legal is %t", uncapped, ok)
+		}
+	}
+}
@@ -0,0 +1,190 @@
+package poker
+
+import "fmt"
+
+// SuitTexture is how the suits of the table cards are distributed.
+type SuitTexture int
+
+const (
+	// RAINBOW has no two cards of the same suit
+	RAINBOW SuitTexture = iota
+	// TWO_TONE has at most two cards of the same suit, so a flush isn't possible yet
+	TWO_TONE
+	// FLUSH_POSSIBLE has three or more cards of the same suit, but not all of them
+	FLUSH_POSSIBLE
+	// MONOTONE has all the cards of the same suit
+	MONOTONE
+)
+
+func (st SuitTexture) String() string {
+	names := [...]string{"Rainbow", "Two-tone", "Flush possible", "Monotone"}
+
+	if st < RAINBOW || st > MONOTONE {
+		return "Unknown SuitTexture"
+	}
+
+	return names[st]
+}
+
+// HighCardClass is how high the highest table card is.
+type HighCardClass int
+
+const (
+	// LOW_BOARD is six high or lower
+	LOW_BOARD HighCardClass = iota
+	// MIDDLE_BOARD is seven, eight or nine high
+	MIDDLE_BOARD
+	// HIGH_BOARD is ten, jack, queen or king high
+	HIGH_BOARD
+	// ACE_HIGH_BOARD has an ace
+	ACE_HIGH_BOARD
+)
+
+func (hc HighCardClass) String() string {
+	names := [...]string{"Low", "Middle", "High", "Ace high"}
+
+	if hc < LOW_BOARD || hc > ACE_HIGH_BOARD {
+		return "Unknown HighCardClass"
+	}
+
+	return names[hc]
+}
+
+// Texture describes the table cards.
+//
+// Paired is true if any rank is repeated, TwoPaired if two ranks have exactly two cards each,
+// and Trips if a rank has three or more cards (so trips and a pair are Paired and Trips, but not TwoPaired).
+// Connectivity is the maximum number of table cards that fit in a straight.
+// Straights and StraightDraws are how many of the 91 rank combinations of two hand cards
+// make a straight or a straight draw (open-ended or gutshot) which the table alone doesn't,
+// and FlushDraws is in how many suits a hand can have a flush draw: the suits with two or three table cards
+// (with four, a single card of the suit makes a flush, and with one, a flush needs two more table cards,
+// so a table with the four suits has none). There are no draws when all the table cards are shown.
+type Texture struct {
+	Paired        bool
+	TwoPaired     bool
+	Trips         bool
+	Suits         SuitTexture
+	HighCard      HighCardClass
+	Connectivity  int
+	Straights     int
+	StraightDraws int
+	FlushDraws    int
+}
+
+// AnalyzeTexture returns the texture of the table cards.
+func AnalyzeTexture(tableCards []Cards) Texture {
+	board := JoinCards(tableCards...)
+	t := Texture{}
+	if board == NO_CARD {
+		return t
+	}
+
+	// Pairs and trips
+	pairs := 0
+	for num := ACES; num >= TWOS; num >>= 1 {
+		switch n := (board & num).Count(); {
+		case n >= 3:
+			t.Trips = true
+		case n == 2:
+			pairs++
+		}
+	}
+	t.Paired = pairs >= 1 || t.Trips
+	t.TwoPaired = pairs >= 2
+
+	// Suits and flush draws
+	maxSuited := 0
+	for _, suit := range []Cards{CLUBS, DIAMONDS, HEARTS, SPADES} {
+		n := (board & suit).Count()
+		if n > maxSuited {
+			maxSuited = n
+		}
+		if (n == 2 || n == 3) && board.Count() < MAX_CARDS_IN_BOARD {
+			t.FlushDraws++
+		}
+	}
+
+	switch {
+	case maxSuited == board.Count() && maxSuited >= 3:
+		t.Suits = MONOTONE
+	case maxSuited >= 3:
+		t.Suits = FLUSH_POSSIBLE
+	case maxSuited == 2:
+		t.Suits = TWO_TONE
+	default:
+		t.Suits = RAINBOW
+	}
+
+	// High card
+	ranks := board.mergeSuits()
+	switch highest, _ := HighCard(ranks); {
+	case highest == ACES&FIRST_SUIT:
+		t.HighCard = ACE_HIGH_BOARD
+	case highest >= TENS&FIRST_SUIT:
+		t.HighCard = HIGH_BOARD
+	case highest >= SEVENS&FIRST_SUIT:
+		t.HighCard = MIDDLE_BOARD
+	default:
+		t.HighCard = LOW_BOARD
+	}
+
+	// Connectivity, the ace also counts as the lowest card
+	lowAce := ranks << 1
+	if ranks.CardsArePresent(ACES) {
+		lowAce |= 1
+	}
+	for shift := 0; shift <= 9; shift++ {
+		if n := ((lowAce >> shift) & 0b11111).Count(); n > t.Connectivity {
+			t.Connectivity = n
+		}
+	}
+
+	// Straights and straight draws of every two ranks in the hand
+	_, boardStraight := Straight(ranks)
+	for num1 := ACES & FIRST_SUIT; num1 >= TWOS&FIRST_SUIT; num1 >>= 1 {
+		for num2 := num1; num2 >= TWOS&FIRST_SUIT; num2 >>= 1 {
+			cards := ranks | num1 | num2
+			if _, found := Straight(cards); found {
+				if !boardStraight {
+					t.Straights++
+				}
+				continue
+			}
+
+			if board.Count() >= MAX_CARDS_IN_BOARD {
+				continue
+			}
+			for num := ACES & FIRST_SUIT; num >= TWOS&FIRST_SUIT; num >>= 1 {
+				_, withHand := Straight(cards | num)
+				_, onlyBoard := Straight(ranks | num)
+				if withHand && !onlyBoard {
+					t.StraightDraws++
+					break
+				}
+			}
+		}
+	}
+
+	return t
+}
+
+// String returns a compact tag of the texture, to group similar tables: high card class (A, H, M or L),
+// pairs (u unpaired, p paired, pp two paired, t trips), suits (r rainbow, tt two-tone, f flush possible, m monotone)
+// and connectivity (c and the number). For example, "H-u-tt-c3" is a high, unpaired, two-tone and connected table.
+func (t Texture) String() string {
+	high := [...]string{"L", "M", "H", "A"}[t.HighCard]
+	suits := [...]string{"r", "tt", "f", "m"}[t.Suits]
+
+	pairs := "u"
+	switch {
+	case t.Trips:
+		pairs = "t"
+	case t.TwoPaired:
+		pairs = "pp"
+	case t.Paired:
+		pairs = "p"
+	}
+
+	return fmt.Sprintf("%s-%s-%s-c%d", high, pairs, suits, t.Connectivity)
+}
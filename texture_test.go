@@ -0,0 +1,87 @@
+package poker_test
+
+import (
+	"testing"
+
+	"github.com/arturo-source/poker-engine"
+)
+
+func TestDryTexture(t *testing.T) {
+	c := poker.NewCard
+	texture := poker.AnalyzeTexture([]poker.Cards{c("Kh"), c("8d"), c("4c")})
+
+	// 76, 75 and 65 are gutshots
+	want := poker.Texture{Suits: poker.RAINBOW, HighCard: poker.HIGH_BOARD, Connectivity: 2, StraightDraws: 3}
+	if want != texture {
+		t.Errorf("\nWant %+v\nGot  %+v", want, texture)
+	}
+	if texture.String() != "H-u-r-c2" {
+		t.Errorf("\nWant %s\nGot  %s", "H-u-r-c2", texture)
+	}
+}
+
+func TestConnectedTexture(t *testing.T) {
+	c := poker.NewCard
+	texture := poker.AnalyzeTexture([]poker.Cards{c("9h"), c("8h"), c("7d")})
+
+	if texture.Suits != poker.TWO_TONE || texture.HighCard != poker.MIDDLE_BOARD || texture.Connectivity != 3 {
+		t.Errorf("Wrong texture: %+v", texture)
+	}
+
+	// JT, T6 and 65
+	if texture.Straights != 3 {
+		t.Errorf("\nWant %d\nGot  %d", 3, texture.Straights)
+	}
+	if texture.FlushDraws != 1 {
+		t.Errorf("\nWant %d\nGot  %d", 1, texture.FlushDraws)
+	}
+	if texture.String() != "M-u-tt-c3" {
+		t.Errorf("\nWant %s\nGot  %s", "M-u-tt-c3", texture)
+	}
+}
+
+func TestPairedAndMonotoneTextures(t *testing.T) {
+	c := poker.NewCard
+
+	monotone := poker.AnalyzeTexture([]poker.Cards{c("Ah"), c("Kh"), c("5h")})
+	if monotone.Suits != poker.MONOTONE || monotone.HighCard != poker.ACE_HIGH_BOARD || monotone.Paired {
+		t.Errorf("Wrong texture: %+v", monotone)
+	}
+
+	trips := poker.AnalyzeTexture([]poker.Cards{c("7h"), c("7d"), c("7s"), c("2c"), c("2h")})
+	if !trips.Paired || !trips.Trips || trips.TwoPaired || trips.StraightDraws != 0 || trips.FlushDraws != 0 {
+		t.Errorf("Wrong texture: %+v", trips)
+	}
+
+	twoPaired := poker.AnalyzeTexture([]poker.Cards{c("7h"), c("7d"), c("2s"), c("2c")})
+	if !twoPaired.Paired || !twoPaired.TwoPaired || twoPaired.Trips || twoPaired.String() != "M-pp-r-c1" {
+		t.Errorf("Wrong texture: %+v", twoPaired)
+	}
+	if trips.String() != "M-t-tt-c1" {
+		t.Errorf("\nWant %s\nGot  %s", "M-t-tt-c1", trips)
+	}
+}
+
+func TestFlushDrawsOnTheTurn(t *testing.T) {
+	c := poker.NewCard
+	tests := []struct {
+		tableCards []poker.Cards
+		suits      poker.SuitTexture
+		flushDraws int
+	}{
+		// A heart makes a flush, so there is no flush draw
+		{[]poker.Cards{c("Ah"), c("Th"), c("6h"), c("2h")}, poker.MONOTONE, 0},
+		// Only the hearts can make a flush draw
+		{[]poker.Cards{c("Ah"), c("Th"), c("6h"), c("2c")}, poker.FLUSH_POSSIBLE, 1},
+		{[]poker.Cards{c("Ah"), c("Th"), c("6c"), c("2c")}, poker.TWO_TONE, 2},
+		// Every suit has one card, a flush needs the river and another turn
+		{[]poker.Cards{c("Ah"), c("Td"), c("6c"), c("2s")}, poker.RAINBOW, 0},
+	}
+
+	for _, tt := range tests {
+		texture := poker.AnalyzeTexture(tt.tableCards)
+		if texture.Suits != tt.suits || texture.FlushDraws != tt.flushDraws {
+			t.Errorf("%v: want %s with %d flush draws, got %s with %d", tt.tableCards, tt.suits, tt.flushDraws, texture.Suits, texture.FlushDraws)
+		}
+	}
+}
@@ -0,0 +1,150 @@
+package tournament
+
+import "math/rand"
+
+// ICMConfig configures how ICM computes the equities.
+// Up to ExactPlayers players (16 if it is 0) the equities are exact, and with more players they are estimated
+// with Samples finishing orders (100000 if it is 0), drawn with Rand (the global source of math/rand if it is nil).
+type ICMConfig struct {
+	ExactPlayers int
+	Samples      int
+	Rand         *rand.Rand
+}
+
+// ICM returns the prize equity of each stack with the Independent Chip Model (Malmuth-Harville),
+// in the same units as payouts (the prize of each finishing position, from the first one).
+// The probability of finishing first is the share of the chips, and the probability of finishing in the next positions
+// is the same with the chips of the players who haven't finished yet.
+// Stacks without coins have no equity, they are treated as players already out of the tournament.
+func ICM(stacks []uint, payouts []float64, config ICMConfig) []float64 {
+	players := make([]int, 0, len(stacks))
+	chips := make([]float64, 0, len(stacks))
+	for i, stack := range stacks {
+		if stack > 0 {
+			players = append(players, i)
+			chips = append(chips, float64(stack))
+		}
+	}
+
+	places := len(payouts)
+	if places > len(players) {
+		places = len(players)
+	}
+
+	exactPlayers := config.ExactPlayers
+	if exactPlayers == 0 {
+		exactPlayers = 16
+	}
+
+	var equities []float64
+	if len(players) <= exactPlayers {
+		equities = exactICM(chips, payouts[:places])
+	} else {
+		equities = sampleICM(chips, payouts[:places], config)
+	}
+
+	result := make([]float64, len(stacks))
+	for i, player := range players {
+		result[player] = equities[i]
+	}
+
+	return result
+}
+
+// exactICM goes through every set of players who can finish in the paid positions,
+// from the first position on, with the probability of reaching it.
+func exactICM(chips []float64, payouts []float64) []float64 {
+	equities := make([]float64, len(chips))
+	if len(payouts) == 0 {
+		return equities
+	}
+
+	var total float64
+	for _, c := range chips {
+		total += c
+	}
+
+	// probs[finished] is the probability that the players in the mask finish first, in any order
+	probs := make([]float64, 1<<len(chips))
+	probs[0] = 1
+	for finished, prob := range probs {
+		if prob == 0 {
+			continue
+		}
+
+		position, left := 0, total
+		for i, c := range chips {
+			if finished&(1<<i) != 0 {
+				position++
+				left -= c
+			}
+		}
+		if position >= len(payouts) {
+			continue
+		}
+
+		for i, c := range chips {
+			if finished&(1<<i) != 0 {
+				continue
+			}
+
+			p := prob * c / left
+			equities[i] += p * payouts[position]
+			probs[finished|1<<i] += p
+		}
+	}
+
+	return equities
+}
+
+// sampleICM averages the payouts of the sampled finishing orders.
+// The finishing order is the same as giving each player an exponential random time with its chips as rate,
+// and sorting the times: the first one finishes first (and so on).
+func sampleICM(chips []float64, payouts []float64, config ICMConfig) []float64 {
+	equities := make([]float64, len(chips))
+	if len(payouts) == 0 {
+		return equities
+	}
+
+	samples := config.Samples
+	if samples == 0 {
+		samples = 100000
+	}
+	exp := rand.ExpFloat64
+	if config.Rand != nil {
+		exp = config.Rand.ExpFloat64
+	}
+
+	// top has the players with the shortest times, sorted
+	top := make([]int, 0, len(payouts))
+	times := make([]float64, len(chips))
+	for n := 0; n < samples; n++ {
+		top = top[:0]
+		for i, c := range chips {
+			times[i] = exp() / c
+
+			if len(top) == len(payouts) && times[i] >= times[top[len(top)-1]] {
+				continue
+			}
+			if len(top) < len(payouts) {
+				top = append(top, i)
+			}
+
+			j := len(top) - 1
+			for ; j > 0 && times[top[j-1]] > times[i]; j-- {
+				top[j] = top[j-1]
+			}
+			top[j] = i
+		}
+
+		for position, player := range top {
+			equities[player] += payouts[position]
+		}
+	}
+
+	for i := range equities {
+		equities[i] /= float64(samples)
+	}
+
+	return equities
+}
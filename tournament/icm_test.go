@@ -0,0 +1,65 @@
+package tournament_test
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/arturo-source/poker-engine/tournament"
+)
+
+func TestExactICM(t *testing.T) {
+	tests := []struct {
+		stacks  []uint
+		payouts []float64
+		want    []float64
+	}{
+		{[]uint{50, 30, 20}, []float64{0.5, 0.3, 0.2}, []float64{0.383929, 0.3275, 0.288571}},
+		{[]uint{5000, 3000, 0, 2000, 1000}, []float64{0.65, 0.35}, []float64{0.406376, 0.287576, 0, 0.201439, 0.104609}},
+		{[]uint{300, 100}, []float64{0.65, 0.35}, []float64{0.575, 0.425}},
+		{[]uint{100, 100, 100}, []float64{1}, []float64{1.0 / 3, 1.0 / 3, 1.0 / 3}},
+		{[]uint{100, 0}, []float64{0.65, 0.35}, []float64{0.65, 0}},
+	}
+
+	for _, tt := range tests {
+		got := tournament.ICM(tt.stacks, tt.payouts, tournament.ICMConfig{})
+		for i := range tt.want {
+			if math.Abs(tt.want[i]-got[i]) > 1e-6 {
+				t.Errorf("Stacks %v\nWant %v\nGot  %v", tt.stacks, tt.want, got)
+				break
+			}
+		}
+	}
+}
+
+func TestSampledICMIsCloseToExact(t *testing.T) {
+	stacks := []uint{9000, 7000, 5000, 5000, 3000, 2000, 1500, 1000, 500}
+	payouts := []float64{0.5, 0.3, 0.2}
+
+	exact := tournament.ICM(stacks, payouts, tournament.ICMConfig{})
+	sampled := tournament.ICM(stacks, payouts, tournament.ICMConfig{ExactPlayers: 1, Samples: 50000, Rand: rand.New(rand.NewSource(1))})
+
+	var sum float64
+	for i := range stacks {
+		sum += sampled[i]
+		if math.Abs(exact[i]-sampled[i]) > 0.005 {
+			t.Errorf("Stack %d\nExact   %f\nSampled %f", stacks[i], exact[i], sampled[i])
+		}
+	}
+	if math.Abs(sum-1) > 1e-9 {
+		t.Errorf("The equities should add up to the prize pool: %f", sum)
+	}
+}
+
+func TestICMOfALargeField(t *testing.T) {
+	stacks := make([]uint, 200)
+	for i := range stacks {
+		stacks[i] = 1000
+	}
+	stacks[0] = 20000
+
+	equities := tournament.ICM(stacks, tournament.DefaultPayouts(len(stacks)), tournament.ICMConfig{Samples: 20000, Rand: rand.New(rand.NewSource(1))})
+	if equities[0] >= 20*equities[1] || equities[0] <= equities[1] {
+		t.Errorf("The chip leader should have more equity than the others, but less than its share of chips: %f %f", equities[0], equities[1])
+	}
+}
@@ -0,0 +1,372 @@
+package tournament
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"sort"
+	"time"
+
+	"github.com/arturo-source/poker-engine"
+)
+
+var errTableSize = errors.New("a table needs at least two seats")
+
+// Move is a player moved from one table to another by MultiTable.Balance.
+// Broken is true if the player was moved because its table was broken.
+type Move struct {
+	Player *poker.Player
+	From   *poker.Game
+	To     *poker.Game
+	Broken bool
+}
+
+// MultiTable is a tournament played at several tables of at most TableSize players.
+// Hands are played in rounds, one hand at every table, and after each round the players who have run out of coins
+// are eliminated and the tables are balanced (see Balance), until everybody left plays at the final table.
+// The blinds go up with the rounds played, or with the time if the Schedule has a Clock.
+//
+// Moves has every move made since the start, in order.
+type MultiTable struct {
+	Tables    []*poker.Game
+	TableSize int
+	Schedule  Schedule
+	PrizePool uint
+	Payouts   []float64
+	Moves     []Move
+
+	rounds     int
+	start      time.Time
+	startCoins map[*poker.Player]uint
+	results    []Result
+}
+
+// NewMultiTable draws the seats of the players, each one starting with startingCoins,
+// at as few tables of tableSize seats as possible, with the same number of players (or one less) at each table,
+// and pays with DefaultPayouts.
+//
+// The seats are drawn with r, which also shuffles the decks of every table, so a tournament run with
+// a rand.Rand with a fixed seed (and agents which don't use randomness) is always the same.
+// If r is nil, the global source of math/rand is used.
+// Returns an error if there are less than two players, tableSize is less than two, or the schedule has no levels.
+func NewMultiTable(players []*poker.Player, startingCoins uint, tableSize int, schedule Schedule, r *rand.Rand) (*MultiTable, error) {
+	if len(players) < 2 {
+		return nil, errNotEnoughPlayers
+	}
+	if tableSize < 2 {
+		return nil, errTableSize
+	}
+	if len(schedule.Levels) == 0 {
+		return nil, errNoLevels
+	}
+
+	shuffle := rand.Shuffle
+	if r != nil {
+		shuffle = r.Shuffle
+	}
+	seats := append([]*poker.Player(nil), players...)
+	shuffle(len(seats), func(i, j int) { seats[i], seats[j] = seats[j], seats[i] })
+
+	tables := make([]*poker.Game, (len(seats)+tableSize-1)/tableSize)
+	for i := range tables {
+		tables[i] = poker.NewGame()
+		tables[i].Deck.Rand = r
+	}
+	for i, p := range seats {
+		p.Coins = startingCoins
+		g := tables[i%len(tables)]
+		g.Players = append(g.Players, p)
+	}
+
+	mt := &MultiTable{
+		Tables:    tables,
+		TableSize: tableSize,
+		Schedule:  schedule,
+		Payouts:   DefaultPayouts(len(players)),
+	}
+	if schedule.Clock != nil {
+		mt.start = schedule.Clock.Now()
+	}
+
+	return mt, nil
+}
+
+// Level returns the current blind level.
+func (mt *MultiTable) Level() Level {
+	return mt.Schedule.Levels[mt.LevelIndex()]
+}
+
+// LevelIndex returns the index of the current blind level in the Schedule.
+func (mt *MultiTable) LevelIndex() int {
+	var elapsed time.Duration
+	if mt.Schedule.Clock != nil {
+		elapsed = mt.Schedule.Clock.Now().Sub(mt.start)
+	}
+
+	return mt.Schedule.level(mt.rounds, elapsed)
+}
+
+// Rounds returns how many rounds of hands have been played.
+func (mt *MultiTable) Rounds() int {
+	return mt.rounds
+}
+
+// IsFinalTable returns true when all the players left play at the same table.
+func (mt *MultiTable) IsFinalTable() bool {
+	return len(mt.Tables) == 1
+}
+
+// PlayRound plays a hand at every table with at least two players, asking each player's agent for its actions,
+// and then eliminates the players who have run out of coins and balances the tables.
+// Returns an error if the tournament is over, or if an agent does an illegal action.
+func (mt *MultiTable) PlayRound(agents map[*poker.Player]poker.Agent) error {
+	if mt.IsOver() {
+		return errTournamentIsOver
+	}
+
+	mt.startCoins = make(map[*poker.Player]uint)
+	level := mt.Level()
+	for i, g := range mt.Tables {
+		if len(g.Players) < 2 {
+			continue
+		}
+		for _, p := range g.Players {
+			mt.startCoins[p] = p.Coins
+		}
+
+		setLevel(g, level)
+		if mt.rounds > 0 {
+			moveButton(g)
+		}
+		if err := g.StartHand(); err != nil {
+			return fmt.Errorf("table %d: %w", i, err)
+		}
+
+		for !g.HandIsOver() {
+			p := g.Players[g.Turn]
+			if err := g.Apply(agents[p].Act(g.ViewFor(p), g.LegalActions())); err != nil {
+				return fmt.Errorf("table %d, agent of %s: %w", i, p.Name, err)
+			}
+		}
+	}
+	mt.rounds++
+
+	mt.eliminate()
+	mt.Balance()
+
+	return nil
+}
+
+// Run plays rounds until the tournament is over, and returns the Results.
+func (mt *MultiTable) Run(agents map[*poker.Player]poker.Agent) ([]Result, error) {
+	for !mt.IsOver() {
+		if err := mt.PlayRound(agents); err != nil {
+			return nil, err
+		}
+	}
+
+	return mt.Results(), nil
+}
+
+// eliminate gives a position to the players who have run out of coins in the last round, at any table.
+// If several players are eliminated in the same round, the one who started it with more coins finishes higher.
+func (mt *MultiTable) eliminate() {
+	busted := make([]*poker.Player, 0)
+	for _, g := range mt.Tables {
+		for _, p := range g.Players {
+			if p.Coins == 0 && mt.startCoins[p] > 0 {
+				busted = append(busted, p)
+			}
+		}
+	}
+	sort.SliceStable(busted, func(i, j int) bool {
+		return mt.startCoins[busted[i]] > mt.startCoins[busted[j]]
+	})
+
+	remaining := mt.Remaining()
+	for i, p := range busted {
+		mt.results = append(mt.results, Result{Player: p, Position: len(remaining) + 1 + i})
+	}
+	if len(remaining) == 1 {
+		mt.results = append(mt.results, Result{Player: remaining[0], Position: 1})
+	}
+}
+
+// Balance removes the players without coins from the tables, and moves players between tables
+// so the ones left play at as few tables as possible, with at most one player of difference between them:
+//   - While there are more tables than needed, the table with fewest players (the last one if several have the same)
+//     is broken, and its players are moved one by one to the tables with fewest players.
+//   - While a table has two players more than another one, a player of the table with most players
+//     is moved to the table with fewest players.
+//
+// The player moved from a table which is not broken is the one who would post the next big blind,
+// and a moved player always takes the seat where it will post the big blind as soon as possible,
+// so nobody can skip the blinds by changing tables.
+// Balance is called after every round by PlayRound, and returns the moves made (which are also added to Moves).
+func (mt *MultiTable) Balance() []Move {
+	remaining := 0
+	for _, g := range mt.Tables {
+		for seat := len(g.Players) - 1; seat >= 0; seat-- {
+			if g.Players[seat].Coins == 0 {
+				removeSeat(g, seat)
+			}
+		}
+		remaining += len(g.Players)
+	}
+
+	moves := make([]Move, 0)
+	needed := (remaining + mt.TableSize - 1) / mt.TableSize
+	if needed < 1 {
+		needed = 1
+	}
+
+	for len(mt.Tables) > needed {
+		broken := len(mt.Tables) - 1
+		for i := len(mt.Tables) - 2; i >= 0; i-- {
+			if len(mt.Tables[i].Players) < len(mt.Tables[broken].Players) {
+				broken = i
+			}
+		}
+
+		from := mt.Tables[broken]
+		mt.Tables = append(mt.Tables[:broken], mt.Tables[broken+1:]...)
+		for len(from.Players) > 0 {
+			p := removeSeat(from, nextBigBlind(from))
+			to := mt.Tables[mt.smallestTable()]
+			seatPlayer(to, p)
+			moves = append(moves, Move{Player: p, From: from, To: to, Broken: true})
+		}
+	}
+
+	for {
+		smallest, largest := mt.smallestTable(), mt.largestTable()
+		from, to := mt.Tables[largest], mt.Tables[smallest]
+		if len(from.Players)-len(to.Players) < 2 {
+			break
+		}
+
+		p := removeSeat(from, nextBigBlind(from))
+		seatPlayer(to, p)
+		moves = append(moves, Move{Player: p, From: from, To: to})
+	}
+
+	mt.Moves = append(mt.Moves, moves...)
+	return moves
+}
+
+// smallestTable returns the index of the first table with fewest players.
+func (mt *MultiTable) smallestTable() int {
+	smallest := 0
+	for i, g := range mt.Tables {
+		if len(g.Players) < len(mt.Tables[smallest].Players) {
+			smallest = i
+		}
+	}
+
+	return smallest
+}
+
+// largestTable returns the index of the first table with most players.
+func (mt *MultiTable) largestTable() int {
+	largest := 0
+	for i, g := range mt.Tables {
+		if len(g.Players) > len(mt.Tables[largest].Players) {
+			largest = i
+		}
+	}
+
+	return largest
+}
+
+// IsOver returns true when only one player has coins.
+func (mt *MultiTable) IsOver() bool {
+	return len(mt.Remaining()) <= 1
+}
+
+// Remaining returns the players who still have coins, table by table.
+func (mt *MultiTable) Remaining() []*poker.Player {
+	remaining := make([]*poker.Player, 0)
+	for _, g := range mt.Tables {
+		for _, p := range g.Players {
+			if p.Coins > 0 {
+				remaining = append(remaining, p)
+			}
+		}
+	}
+
+	return remaining
+}
+
+// Results returns the players who have finished the tournament sorted by position (the winner first, once it is over),
+// with their prizes.
+func (mt *MultiTable) Results() []Result {
+	results := append([]Result(nil), mt.results...)
+	sort.Slice(results, func(i, j int) bool { return results[i].Position < results[j].Position })
+	payPrizes(results, mt.PrizePool, mt.Payouts)
+
+	return results
+}
+
+// handsUntilBigBlind returns how many hands have to be played at a table of n players
+// until the one at seat posts the big blind, if the button is at that seat now and moves one seat after every hand.
+func handsUntilBigBlind(button, seat, n int) int {
+	// The next big blind is two seats to the left of the next button, and heads-up the next button posts the small blind
+	offset := 3
+	if n <= 2 {
+		offset = 2
+	}
+
+	return ((seat-button-offset)%n + n) % n
+}
+
+// nextBigBlind returns the seat of the player who would post the next big blind.
+func nextBigBlind(g *poker.Game) int {
+	for seat := range g.Players {
+		if handsUntilBigBlind(g.Button, seat, len(g.Players)) == 0 {
+			return seat
+		}
+	}
+
+	return 0
+}
+
+// removeSeat removes the player at the seat from the table, and returns it.
+// The button stays with the same player, and if it is the one removed,
+// it goes to the previous seat, so it moves to the next player after the next hand.
+func removeSeat(g *poker.Game, seat int) *poker.Player {
+	p := g.Players[seat]
+	g.Players = append(g.Players[:seat], g.Players[seat+1:]...)
+
+	if seat <= g.Button {
+		g.Button--
+	}
+	if g.Button < 0 {
+		g.Button = len(g.Players) - 1
+	}
+	if g.Button < 0 {
+		g.Button = 0
+	}
+
+	return p
+}
+
+// seatPlayer sits the player at the table in the seat where it will post the big blind as soon as possible,
+// keeping the button with the same player.
+func seatPlayer(g *poker.Game, p *poker.Player) {
+	best, bestHands := 0, len(g.Players)+1
+	for seat := 0; seat <= len(g.Players); seat++ {
+		button := g.Button
+		if len(g.Players) > 0 && seat <= g.Button {
+			button++
+		}
+
+		if hands := handsUntilBigBlind(button, seat, len(g.Players)+1); hands < bestHands {
+			best, bestHands = seat, hands
+		}
+	}
+
+	if len(g.Players) > 0 && best <= g.Button {
+		g.Button++
+	}
+	g.Players = append(g.Players[:best], append([]*poker.Player{p}, g.Players[best:]...)...)
+}
@@ -0,0 +1,164 @@
+package tournament_test
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/arturo-source/poker-engine"
+	"github.com/arturo-source/poker-engine/tournament"
+)
+
+func tableSizes(mt *tournament.MultiTable) []int {
+	sizes := make([]int, len(mt.Tables))
+	for i, g := range mt.Tables {
+		sizes[i] = len(g.Players)
+	}
+
+	return sizes
+}
+
+func equalInts(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+func TestNewMultiTableSeatsPlayersEvenly(t *testing.T) {
+	mt, err := tournament.NewMultiTable(newPlayers(20), 100, 9, schedule, rand.New(rand.NewSource(1)))
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	if want, got := []int{7, 7, 6}, tableSizes(mt); !equalInts(want, got) {
+		t.Errorf("\nWant tables %v\nGot  %v", want, got)
+	}
+	if mt.IsFinalTable() {
+		t.Errorf("Three tables are not the final table")
+	}
+
+	if _, err := tournament.NewMultiTable(newPlayers(20), 100, 1, schedule, nil); err == nil {
+		t.Errorf("Wanted an error with tables of one seat. Got nil.")
+	}
+}
+
+func TestBalanceMovesTheNextBigBlind(t *testing.T) {
+	mt, _ := tournament.NewMultiTable(newPlayers(10), 100, 5, schedule, rand.New(rand.NewSource(1)))
+	short, long := mt.Tables[0], mt.Tables[1]
+	short.Players[1].Coins = 0
+	short.Players[3].Coins = 0
+	long.Button = 1
+	button := long.Players[long.Button]
+	nextBigBlind := long.Players[(long.Button+3)%5]
+
+	moves := mt.Balance()
+	if len(moves) != 1 {
+		t.Fatalf("\nWant %d moves\nGot  %d", 1, len(moves))
+	}
+	if m := moves[0]; m.Player != nextBigBlind || m.From != long || m.To != short || m.Broken {
+		t.Errorf("Wrong move %+v, the next big blind %s should move", m, nextBigBlind.Name)
+	}
+
+	if want, got := []int{4, 4}, tableSizes(mt); !equalInts(want, got) {
+		t.Errorf("\nWant tables %v\nGot  %v", want, got)
+	}
+	if p := short.Players[(short.Button+3)%4]; p != nextBigBlind {
+		t.Errorf("The moved player should post the next big blind, but %s does", p.Name)
+	}
+	if p := long.Players[long.Button]; p != button {
+		t.Errorf("The button should stay with the same player")
+	}
+}
+
+func TestBalanceBreaksTablesUntilTheFinalTable(t *testing.T) {
+	mt, _ := tournament.NewMultiTable(newPlayers(15), 100, 6, schedule, rand.New(rand.NewSource(1)))
+	if want, got := []int{5, 5, 5}, tableSizes(mt); !equalInts(want, got) {
+		t.Fatalf("\nWant tables %v\nGot  %v", want, got)
+	}
+
+	mt.Tables[0].Players[0].Coins = 0
+	mt.Tables[1].Players[0].Coins = 0
+	mt.Tables[2].Players[0].Coins = 0
+	broken := mt.Tables[2]
+
+	moves := mt.Balance()
+	if want, got := []int{6, 6}, tableSizes(mt); !equalInts(want, got) {
+		t.Errorf("\nWant tables %v\nGot  %v", want, got)
+	}
+	if len(moves) != 4 {
+		t.Fatalf("\nWant %d moves\nGot  %d", 4, len(moves))
+	}
+	for _, m := range moves {
+		if m.From != broken || !m.Broken {
+			t.Errorf("Every player should come from the broken table: %+v", m)
+		}
+	}
+
+	for i := 0; i < 3; i++ {
+		mt.Tables[0].Players[i].Coins = 0
+		mt.Tables[1].Players[i].Coins = 0
+	}
+	mt.Balance()
+	if !mt.IsFinalTable() || len(mt.Tables[0].Players) != 6 {
+		t.Errorf("Six players should be at the final table: %v", tableSizes(mt))
+	}
+	if len(mt.Moves) != 4+3 {
+		t.Errorf("\nWant %d moves\nGot  %d", 7, len(mt.Moves))
+	}
+}
+
+func runMultiTable(t *testing.T, seed int64) []tournament.Result {
+	players := newPlayers(12)
+	mt, _ := tournament.NewMultiTable(players, 100, 5, schedule, rand.New(rand.NewSource(seed)))
+	mt.PrizePool = 1200
+
+	agents := make(map[*poker.Player]poker.Agent)
+	for i, p := range players {
+		if i%2 == 0 {
+			agents[p] = allInAgent{}
+		} else {
+			agents[p] = callingAgent{}
+		}
+	}
+
+	results, err := mt.Run(agents)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	return results
+}
+
+func TestMultiTableIsDeterministic(t *testing.T) {
+	results := runMultiTable(t, 7)
+	if len(results) != 12 {
+		t.Fatalf("\nWant %d results\nGot  %d", 12, len(results))
+	}
+
+	var prizes uint
+	for i, res := range results {
+		if res.Position != i+1 {
+			t.Errorf("\nWant position %d\nGot  %d", i+1, res.Position)
+		}
+		prizes += res.Prize
+	}
+	if prizes != 1200 {
+		t.Errorf("\nWant prizes %d\nGot  %d", 1200, prizes)
+	}
+	if results[0].Player.Coins != 1200 {
+		t.Errorf("The winner should have every coin: %d", results[0].Player.Coins)
+	}
+
+	again := runMultiTable(t, 7)
+	for i := range results {
+		if results[i].Player.Name != again[i].Player.Name {
+			t.Errorf("Position %d\nWant %s\nGot  %s", i+1, results[i].Player.Name, again[i].Player.Name)
+		}
+	}
+}
@@ -0,0 +1,115 @@
+package tournament
+
+import (
+	"errors"
+	"math/rand"
+
+	"github.com/arturo-source/poker-engine"
+)
+
+var errPushFoldSpot = errors.New("the pusher and the caller must be two different players with coins")
+
+// PushFold is a preflop spot where Pusher goes all-in, and Caller, the only other player left in the hand,
+// calls with the hands of CallingRange (nil calls with every hand), or folds.
+// Stacks are the coins of every player at the start of the hand, and Posted what each one has put in the pot
+// before the push, as blinds or antes (nil if nobody has put anything). If Pusher folds, Caller wins the pot.
+//
+// The ICM equities are computed with Payouts and the ICM config, and the equity of the hand against CallingRange
+// with Samples deals (10000 if it is 0) drawn with Rand (the global source of math/rand if it is nil).
+type PushFold struct {
+	Stacks       []uint
+	Posted       []uint
+	Pusher       int
+	Caller       int
+	CallingRange poker.Range
+	Payouts      []float64
+	ICM          ICMConfig
+	Samples      int
+	Rand         *rand.Rand
+}
+
+// EV returns the ICM equity of Pusher if it pushes with the hand, and if it folds it, in the units of Payouts.
+// Returns an error if Pusher and Caller are not two different players with coins.
+func (pf PushFold) EV(hand poker.Cards) (push, fold float64, err error) {
+	if pf.Pusher == pf.Caller || pf.Pusher < 0 || pf.Caller < 0 || pf.Pusher >= len(pf.Stacks) || pf.Caller >= len(pf.Stacks) ||
+		pf.Stacks[pf.Pusher] == 0 || pf.Stacks[pf.Caller] == 0 {
+		return 0, 0, errPushFoldSpot
+	}
+
+	stacks, pot := pf.afterPosting()
+	pusher, caller := pf.Stacks[pf.Pusher], pf.Stacks[pf.Caller]
+	posted := pf.Stacks[pf.Pusher] - stacks[pf.Pusher]
+	dead := pot - posted - (pf.Stacks[pf.Caller] - stacks[pf.Caller])
+
+	fold = pf.equity(stacks, stacks[pf.Pusher], caller+posted+dead)
+	stolen := pf.equity(stacks, pusher+pot-posted, stacks[pf.Caller])
+
+	pCall := pf.callProbability(hand)
+	if pCall == 0 {
+		return stolen, fold, nil
+	}
+
+	samples := pf.Samples
+	if samples == 0 {
+		samples = 10000
+	}
+	hs, err := poker.SampleHandStrength(hand, nil, pf.CallingRange, samples, pf.Rand)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	called := minUint(pusher, caller)
+	win := pf.equity(stacks, pusher+called+dead, caller-called)
+	lose := pf.equity(stacks, pusher-called, caller+called+dead)
+	push = (1-pCall)*stolen + pCall*(hs.Equity*win+(1-hs.Equity)*lose)
+
+	return push, fold, nil
+}
+
+// afterPosting returns the stacks after posting the blinds and antes, and the coins in the pot.
+func (pf PushFold) afterPosting() ([]uint, uint) {
+	stacks := append([]uint(nil), pf.Stacks...)
+	var pot uint
+	for i, posted := range pf.Posted {
+		if i < len(stacks) {
+			posted = minUint(posted, stacks[i])
+			stacks[i] -= posted
+			pot += posted
+		}
+	}
+
+	return stacks, pot
+}
+
+// equity returns the ICM equity of Pusher when Pusher and Caller end the hand with those coins.
+func (pf PushFold) equity(stacks []uint, pusher, caller uint) float64 {
+	stacks = append([]uint(nil), stacks...)
+	stacks[pf.Pusher], stacks[pf.Caller] = pusher, caller
+
+	return ICM(stacks, pf.Payouts, pf.ICM)[pf.Pusher]
+}
+
+// callProbability returns how likely is Caller to call, counting the hands of CallingRange without the cards of the hand.
+func (pf PushFold) callProbability(hand poker.Cards) float64 {
+	if pf.CallingRange == nil {
+		return 1
+	}
+
+	var calls float64
+	for h, weight := range pf.CallingRange {
+		if !h.CardsArePresent(hand) {
+			calls += weight
+		}
+	}
+
+	left := float64(poker.MAX_CARDS - hand.Count())
+	return calls / (left * (left - 1) / 2)
+}
+
+func minUint(a, b uint) uint {
+	if a < b {
+		return a
+	}
+
+	return b
+}
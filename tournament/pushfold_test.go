@@ -0,0 +1,76 @@
+package tournament_test
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/arturo-source/poker-engine"
+	"github.com/arturo-source/poker-engine/tournament"
+)
+
+func TestPushFoldWhenNobodyCalls(t *testing.T) {
+	c := poker.NewCard
+	payouts := []float64{0.5, 0.3, 0.2}
+	pf := tournament.PushFold{
+		Stacks:       []uint{1000, 2000, 3000, 4000},
+		Posted:       []uint{10, 50, 100, 10},
+		Pusher:       1,
+		Caller:       2,
+		CallingRange: poker.Range{},
+		Payouts:      payouts,
+	}
+
+	push, fold, err := pf.EV(c("7h") | c("2c"))
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	wantPush := tournament.ICM([]uint{990, 2120, 2900, 3990}, payouts, tournament.ICMConfig{})[1]
+	wantFold := tournament.ICM([]uint{990, 1950, 3070, 3990}, payouts, tournament.ICMConfig{})[1]
+	if math.Abs(wantPush-push) > 1e-9 || math.Abs(wantFold-fold) > 1e-9 {
+		t.Errorf("\nWant push %f, fold %f\nGot  push %f, fold %f", wantPush, wantFold, push, fold)
+	}
+}
+
+func TestPushFoldOnTheBubble(t *testing.T) {
+	c := poker.NewCard
+	pf := tournament.PushFold{
+		Stacks:  []uint{5000, 5000, 300},
+		Posted:  []uint{50, 100, 0},
+		Pusher:  0,
+		Caller:  1,
+		Payouts: []float64{0.65, 0.35},
+		Rand:    rand.New(rand.NewSource(1)),
+	}
+
+	push, fold, err := pf.EV(c("Ah") | c("Ad"))
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if push <= fold {
+		t.Errorf("Aces should be pushed: push %f, fold %f", push, fold)
+	}
+
+	// Even with some edge against any hand, risking the whole stack is bad when the short stack is about to bust
+	push, fold, err = pf.EV(c("8h") | c("7h"))
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if push >= fold {
+		t.Errorf("Eight-seven shouldn't be pushed into a calling stack: push %f, fold %f", push, fold)
+	}
+}
+
+func TestPushFoldErrors(t *testing.T) {
+	c := poker.NewCard
+	pf := tournament.PushFold{Stacks: []uint{100, 0, 100}, Pusher: 0, Caller: 1, Payouts: []float64{1}}
+	if _, _, err := pf.EV(c("Ah") | c("Ad")); err == nil {
+		t.Errorf("Wanted an error with a caller without coins. Got nil.")
+	}
+
+	pf.Caller = 0
+	if _, _, err := pf.EV(c("Ah") | c("Ad")); err == nil {
+		t.Errorf("Wanted an error when the pusher is the caller. Got nil.")
+	}
+}
@@ -0,0 +1,83 @@
+package tournament
+
+import "time"
+
+// Level is a blind level of the tournament. It lasts Hands hands, or Duration if the Schedule has a Clock.
+type Level struct {
+	SmallBlind uint
+	BigBlind   uint
+	Ante       uint
+	Hands      int
+	Duration   time.Duration
+}
+
+// Schedule is the list of blind levels of a tournament. The last level never ends.
+// If Clock is nil, the levels go up by the number of hands played, and if it is not nil, by the time passed.
+type Schedule struct {
+	Levels []Level
+	Clock  Clock
+}
+
+// level returns the index of the level after playing that many hands, or after that much time since the start.
+func (s Schedule) level(hands int, elapsed time.Duration) int {
+	for i, l := range s.Levels[:len(s.Levels)-1] {
+		if s.Clock == nil {
+			if hands < l.Hands {
+				return i
+			}
+			hands -= l.Hands
+			continue
+		}
+
+		if elapsed < l.Duration {
+			return i
+		}
+		elapsed -= l.Duration
+	}
+
+	return len(s.Levels) - 1
+}
+
+// Clock tells the time, so the blind levels can go up with the time without depending on the real one.
+type Clock interface {
+	Now() time.Time
+}
+
+// SystemClock is the real time.
+type SystemClock struct{}
+
+// Now implements Clock.
+func (SystemClock) Now() time.Time {
+	return time.Now()
+}
+
+// ManualClock only moves forward when Advance is called.
+type ManualClock struct {
+	Time time.Time
+}
+
+// Now implements Clock.
+func (c *ManualClock) Now() time.Time {
+	return c.Time
+}
+
+// Advance moves the clock forward.
+func (c *ManualClock) Advance(d time.Duration) {
+	c.Time = c.Time.Add(d)
+}
+
+// DefaultPayouts returns the usual fractions of the prize pool for each finishing position (first, second, etc.),
+// depending on the number of entrants: the winner takes everything heads-up, two places are paid up to 6 entrants,
+// three places up to 10, and five places with more.
+func DefaultPayouts(entrants int) []float64 {
+	switch {
+	case entrants <= 2:
+		return []float64{1}
+	case entrants <= 6:
+		return []float64{0.65, 0.35}
+	case entrants <= 10:
+		return []float64{0.5, 0.3, 0.2}
+	default:
+		return []float64{0.4, 0.25, 0.15, 0.11, 0.09}
+	}
+}
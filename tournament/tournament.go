@@ -0,0 +1,259 @@
+// Package tournament plays poker tournaments on top of poker.Game: the blinds go up following a Schedule,
+// players are eliminated when they run out of coins, and the prize pool is paid by finishing position.
+package tournament
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/arturo-source/poker-engine"
+)
+
+var (
+	errNoLevels         = errors.New("the schedule has no levels")
+	errNotEnoughPlayers = errors.New("a tournament needs at least two players")
+	errTournamentIsOver = errors.New("the tournament is over")
+	errHandIsNotOver    = errors.New("the hand is not over yet")
+	errHandNotFinished  = errors.New("the previous hand has not been finished")
+	errNoHandToFinish   = errors.New("there is no hand to finish")
+)
+
+// Result is how a player finished the tournament: its Position (1 is the winner) and the Prize it won.
+type Result struct {
+	Player   *poker.Player
+	Position int
+	Prize    uint
+}
+
+// Tournament plays hands of Game until only one player has coins.
+// The blinds and the ante of each hand are the ones of the current level of the Schedule,
+// and the button moves to the next player with coins after every hand.
+//
+// PrizePool is divided between the first positions with Payouts (the fraction of the prize pool of each position,
+// from the first one), and the coins left when rounding go to the winner.
+type Tournament struct {
+	Game      *poker.Game
+	Schedule  Schedule
+	PrizePool uint
+	Payouts   []float64
+
+	hands      int
+	start      time.Time
+	startCoins []uint
+	results    []Result
+}
+
+// New creates a tournament between the players, each one starting with startingCoins,
+// and paying with DefaultPayouts.
+// Returns an error if there are less than two players, or the schedule has no levels.
+func New(players []*poker.Player, startingCoins uint, schedule Schedule) (*Tournament, error) {
+	if len(players) < 2 {
+		return nil, errNotEnoughPlayers
+	}
+	if len(schedule.Levels) == 0 {
+		return nil, errNoLevels
+	}
+
+	g := poker.NewGame()
+	for _, p := range players {
+		p.Coins = startingCoins
+		g.Players = append(g.Players, p)
+	}
+
+	t := &Tournament{
+		Game:     g,
+		Schedule: schedule,
+		Payouts:  DefaultPayouts(len(players)),
+	}
+	if schedule.Clock != nil {
+		t.start = schedule.Clock.Now()
+	}
+
+	return t, nil
+}
+
+// Level returns the current blind level.
+func (t *Tournament) Level() Level {
+	return t.Schedule.Levels[t.LevelIndex()]
+}
+
+// LevelIndex returns the index of the current blind level in the Schedule.
+func (t *Tournament) LevelIndex() int {
+	var elapsed time.Duration
+	if t.Schedule.Clock != nil {
+		elapsed = t.Schedule.Clock.Now().Sub(t.start)
+	}
+
+	return t.Schedule.level(t.hands, elapsed)
+}
+
+// Hands returns how many hands have been played.
+func (t *Tournament) Hands() int {
+	return t.hands
+}
+
+// StartHand sets the blinds of the current level, moves the button, and starts a hand of Game.
+// Returns an error if the tournament is over, or if the previous hand is not over or hasn't been finished with FinishHand.
+func (t *Tournament) StartHand() error {
+	if t.IsOver() {
+		return errTournamentIsOver
+	}
+
+	g := t.Game
+	if t.startCoins != nil {
+		if !g.HandIsOver() {
+			return errHandIsNotOver
+		}
+		return errHandNotFinished
+	}
+
+	setLevel(g, t.Level())
+	if t.hands > 0 {
+		moveButton(g)
+	}
+
+	t.startCoins = make([]uint, len(g.Players))
+	for i, p := range g.Players {
+		t.startCoins[i] = p.Coins
+	}
+
+	return g.StartHand()
+}
+
+// FinishHand eliminates the players who have run out of coins in the hand.
+// If several players are eliminated in the same hand, the one who started it with more coins finishes higher.
+// Returns an error if the hand is not over, or if it has already been finished.
+func (t *Tournament) FinishHand() error {
+	g := t.Game
+	if t.startCoins == nil {
+		return errNoHandToFinish
+	}
+	if !g.HandIsOver() {
+		return errHandIsNotOver
+	}
+	t.hands++
+
+	busted := make([]int, 0)
+	for seat, p := range g.Players {
+		if p.Coins == 0 && t.startCoins[seat] > 0 {
+			busted = append(busted, seat)
+		}
+	}
+	sort.SliceStable(busted, func(i, j int) bool {
+		return t.startCoins[busted[i]] > t.startCoins[busted[j]]
+	})
+
+	remaining := len(t.Remaining())
+	for i, seat := range busted {
+		t.results = append(t.results, Result{Player: g.Players[seat], Position: remaining + 1 + i})
+	}
+	if remaining == 1 {
+		t.results = append(t.results, Result{Player: t.Remaining()[0], Position: 1})
+	}
+	t.startCoins = nil
+
+	return nil
+}
+
+// PlayHand plays a hand asking each agent for its actions (agents[i] plays for Game.Players[i]).
+// Returns an error if the tournament is over, or if an agent does an illegal action.
+func (t *Tournament) PlayHand(agents []poker.Agent) error {
+	if err := t.StartHand(); err != nil {
+		return err
+	}
+
+	g := t.Game
+	for !g.HandIsOver() {
+		action := agents[g.Turn].Act(g.ViewFor(g.Players[g.Turn]), g.LegalActions())
+		if err := g.Apply(action); err != nil {
+			return fmt.Errorf("agent %d: %w", g.Turn, err)
+		}
+	}
+
+	return t.FinishHand()
+}
+
+// Run plays hands until the tournament is over, and returns the Results.
+func (t *Tournament) Run(agents []poker.Agent) ([]Result, error) {
+	for !t.IsOver() {
+		if err := t.PlayHand(agents); err != nil {
+			return nil, err
+		}
+	}
+
+	return t.Results(), nil
+}
+
+// IsOver returns true when only one player has coins.
+func (t *Tournament) IsOver() bool {
+	return len(t.Remaining()) <= 1
+}
+
+// Remaining returns the players who still have coins.
+func (t *Tournament) Remaining() []*poker.Player {
+	remaining := make([]*poker.Player, 0, len(t.Game.Players))
+	for _, p := range t.Game.Players {
+		if p.Coins > 0 {
+			remaining = append(remaining, p)
+		}
+	}
+
+	return remaining
+}
+
+// Results returns the players who have finished the tournament sorted by position (the winner first, once it is over),
+// with their prizes.
+func (t *Tournament) Results() []Result {
+	results := append([]Result(nil), t.results...)
+	sort.Slice(results, func(i, j int) bool { return results[i].Position < results[j].Position })
+
+	payPrizes(results, t.PrizePool, t.Payouts)
+
+	return results
+}
+
+// setLevel sets the blinds and the ante of the level in the game.
+func setLevel(g *poker.Game, level Level) {
+	g.SmallBlind, g.BigBlind, g.Ante = level.SmallBlind, level.BigBlind, level.Ante
+}
+
+// moveButton moves the button to the next player with coins.
+func moveButton(g *poker.Game) {
+	for i := 1; i <= len(g.Players); i++ {
+		if seat := (g.Button + i) % len(g.Players); g.Players[seat].Coins > 0 {
+			g.Button = seat
+			return
+		}
+	}
+}
+
+// payPrizes sets the Prize of the results from their positions.
+func payPrizes(results []Result, prizePool uint, payouts []float64) {
+	prizes := prizes(prizePool, payouts)
+	for i := range results {
+		if pos := results[i].Position; pos <= len(prizes) {
+			results[i].Prize = prizes[pos-1]
+		}
+	}
+}
+
+// prizes returns the prize of each paid position, giving the coins lost when rounding to the winner.
+func prizes(prizePool uint, payouts []float64) []uint {
+	prizes := make([]uint, len(payouts))
+	var fractions float64
+	var paid uint
+	for i, fraction := range payouts {
+		prizes[i] = uint(float64(prizePool) * fraction)
+		paid += prizes[i]
+		fractions += fraction
+	}
+
+	if total := uint(math.Round(float64(prizePool) * fractions)); len(prizes) > 0 && total > paid {
+		prizes[0] += total - paid
+	}
+
+	return prizes
+}
@@ -0,0 +1,193 @@
+package tournament_test
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/arturo-source/poker-engine"
+	"github.com/arturo-source/poker-engine/tournament"
+)
+
+// allInAgent always bets or raises all its coins, or calls if it can't.
+type allInAgent struct{}
+
+func (allInAgent) Act(view poker.GameView, legalActions []poker.Action) poker.Action {
+	return legalActions[len(legalActions)-1]
+}
+
+// callingAgent always checks or calls.
+type callingAgent struct{}
+
+func (callingAgent) Act(view poker.GameView, legalActions []poker.Action) poker.Action {
+	for _, a := range legalActions {
+		if a.Kind == poker.CHECK || a.Kind == poker.CALL {
+			return a
+		}
+	}
+
+	return legalActions[0]
+}
+
+func newPlayers(n int) []*poker.Player {
+	players := make([]*poker.Player, n)
+	for i := range players {
+		players[i] = poker.NewPlayer(fmt.Sprintf("P%d", i))
+	}
+
+	return players
+}
+
+var schedule = tournament.Schedule{
+	Levels: []tournament.Level{
+		{SmallBlind: 1, BigBlind: 2, Hands: 2},
+		{SmallBlind: 2, BigBlind: 4, Hands: 2},
+		{SmallBlind: 5, BigBlind: 10, Ante: 1},
+	},
+}
+
+func TestTournamentRunsUntilOnePlayerIsLeft(t *testing.T) {
+	players := newPlayers(6)
+	tour, err := tournament.New(players, 100, schedule)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	tour.PrizePool = 1000
+	tour.Game.Deck.Rand = rand.New(rand.NewSource(1))
+
+	agents := []poker.Agent{allInAgent{}, callingAgent{}, allInAgent{}, callingAgent{}, allInAgent{}, callingAgent{}}
+	results, err := tour.Run(agents)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	if len(results) != 6 {
+		t.Fatalf("\nWant %d results\nGot  %d", 6, len(results))
+	}
+
+	var prizes uint
+	for i, res := range results {
+		if res.Position != i+1 {
+			t.Errorf("\nWant position %d\nGot  %d", i+1, res.Position)
+		}
+		prizes += res.Prize
+	}
+	if prizes != 1000 || results[0].Prize != 650 || results[1].Prize != 350 {
+		t.Errorf("Wrong prizes: %v", results)
+	}
+
+	if results[0].Player.Coins != 600 {
+		t.Errorf("The winner should have every coin: %d", results[0].Player.Coins)
+	}
+	if _, err := tour.Run(agents); err != nil || tour.StartHand() == nil {
+		t.Errorf("No more hands should be played when the tournament is over")
+	}
+}
+
+func TestBlindLevelsByHands(t *testing.T) {
+	tour, _ := tournament.New(newPlayers(3), 1000, schedule)
+	agents := []poker.Agent{callingAgent{}, callingAgent{}, callingAgent{}}
+
+	wantLevels := []int{0, 0, 1, 1, 2, 2, 2}
+	for hand, want := range wantLevels {
+		if got := tour.LevelIndex(); want != got {
+			t.Errorf("Hand %d\nWant level %d\nGot  %d", hand, want, got)
+		}
+		if err := tour.PlayHand(agents); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+	}
+
+	if tour.Game.BigBlind != 10 || tour.Game.Ante != 1 {
+		t.Errorf("Wrong blinds: %d %d", tour.Game.BigBlind, tour.Game.Ante)
+	}
+}
+
+func TestBlindLevelsByClock(t *testing.T) {
+	clock := &tournament.ManualClock{Time: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+	s := tournament.Schedule{
+		Levels: []tournament.Level{
+			{SmallBlind: 1, BigBlind: 2, Duration: 10 * time.Minute},
+			{SmallBlind: 2, BigBlind: 4, Duration: 10 * time.Minute},
+			{SmallBlind: 5, BigBlind: 10},
+		},
+		Clock: clock,
+	}
+	tour, _ := tournament.New(newPlayers(2), 1000, s)
+
+	for _, want := range []int{0, 0, 1, 1, 2} {
+		if got := tour.LevelIndex(); want != got {
+			t.Errorf("\nWant level %d\nGot  %d", want, got)
+		}
+		clock.Advance(6 * time.Minute)
+	}
+}
+
+func TestButtonMovesAndHandMustBeFinished(t *testing.T) {
+	tour, _ := tournament.New(newPlayers(3), 1000, schedule)
+	if err := tour.StartHand(); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if err := tour.StartHand(); err == nil {
+		t.Errorf("Wanted an error starting a hand before the last one is over. Got nil.")
+	}
+	if err := tour.FinishHand(); err == nil {
+		t.Errorf("Wanted an error finishing a hand which is not over. Got nil.")
+	}
+
+	g := tour.Game
+	for !g.HandIsOver() {
+		g.Apply(callingAgent{}.Act(g.ViewFor(g.Players[g.Turn]), g.LegalActions()))
+	}
+	if err := tour.FinishHand(); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	button := g.Button
+
+	tour.StartHand()
+	if g.Button != (button+1)%3 {
+		t.Errorf("\nWant button %d\nGot  %d", (button+1)%3, g.Button)
+	}
+}
+
+func TestHandIsFinishedOnce(t *testing.T) {
+	players := newPlayers(3)
+	tour, _ := tournament.New(players, 100, schedule)
+	tour.Game.Deck.Rand = rand.New(rand.NewSource(1))
+	g := tour.Game
+
+	// Play until somebody is eliminated
+	for len(tour.Results()) == 0 {
+		if err := tour.StartHand(); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		for !g.HandIsOver() {
+			g.Apply(allInAgent{}.Act(g.ViewFor(g.Players[g.Turn]), g.LegalActions()))
+		}
+
+		if err := tour.StartHand(); err == nil {
+			t.Fatalf("Wanted an error starting a hand before the last one is finished. Got nil.")
+		}
+		if err := tour.FinishHand(); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+	}
+
+	hands, results := tour.Hands(), len(tour.Results())
+	if err := tour.FinishHand(); err == nil {
+		t.Errorf("Wanted an error finishing the same hand twice. Got nil.")
+	}
+	if tour.Hands() != hands || len(tour.Results()) != results {
+		t.Errorf("\nWant %d hands and %d results\nGot  %d hands and %d results", hands, results, tour.Hands(), len(tour.Results()))
+	}
+}
+
+func TestNewTournamentErrors(t *testing.T) {
+	if _, err := tournament.New(newPlayers(1), 100, schedule); err == nil {
+		t.Errorf("Wanted an error with one player. Got nil.")
+	}
+	if _, err := tournament.New(newPlayers(2), 100, tournament.Schedule{}); err == nil {
+		t.Errorf("Wanted an error without levels. Got nil.")
+	}
+}
@@ -0,0 +1,87 @@
+package poker
+
+// KUHN is Kuhn poker: a deck of three cards (J, Q, K), one card per player, and no table cards.
+// The highest card wins.
+var KUHN = &Variant{
+	Name:      "Kuhn poker",
+	Deck:      (JACKS | QUEENS | KINGS) & SPADES,
+	HandCards: 1,
+	HandValue: func(hand, tableCards Cards) int {
+		return cardRank(hand)
+	},
+}
+
+// LEDUC is Leduc hold'em: a deck of six cards (two suits of J, Q, K), one card per player,
+// and one table card after the first betting round.
+// A player paired with the table card wins, otherwise the highest card wins.
+var LEDUC = &Variant{
+	Name:       "Leduc hold'em",
+	Deck:       (JACKS | QUEENS | KINGS) & (SPADES | HEARTS),
+	HandCards:  1,
+	TableCards: []int{1},
+	HandValue: func(hand, tableCards Cards) int {
+		if tableCards != NO_CARD && cardRank(hand) == cardRank(tableCards) {
+			return 13 + cardRank(hand)
+		}
+		return cardRank(hand)
+	},
+}
+
+// NewKuhnGame creates a Kuhn poker game between the players, with the usual rules:
+// everybody antes 1 coin, and there is only one betting round where only one bet of 1 coin is allowed.
+func NewKuhnGame(players ...*Player) *Game {
+	g := NewVariantGame(KUHN)
+	g.Players = players
+	g.Ante = 1
+	g.Structure = FixedLimit{SmallBet: 1, BigBet: 1, MaxRaises: 1}
+
+	return g
+}
+
+// NewLeducGame creates a Leduc hold'em game between the players, with the usual rules:
+// everybody antes 1 coin, bets and raises are of 2 coins in the first round and of 4 in the second one,
+// and only a bet and a raise are allowed per round.
+func NewLeducGame(players ...*Player) *Game {
+	g := NewVariantGame(LEDUC)
+	g.Players = players
+	g.Ante = 1
+	g.Structure = FixedLimit{SmallBet: 2, BigBet: 4, BigBetState: FLOP, MaxRaises: 2}
+
+	return g
+}
+
+// Deals returns every possible order of the cards dealt in a hand of the variant between that many players
+// (hand cards, burned cards and table cards), to be used as the deals of a CFRSolver.
+// Only use it for variants with small decks, the number of deals grows very fast.
+func (v *Variant) Deals(players int) [][]Cards {
+	n := v.HandCards * players
+	for _, tableCards := range v.TableCards {
+		n += tableCards
+		if v.BurnCards {
+			n++
+		}
+	}
+
+	deck := NewDeckFrom(v.Deck).cards
+	deals := make([][]Cards, 0)
+	deal := make([]Cards, 0, n)
+
+	var generate func(used Cards)
+	generate = func(used Cards) {
+		if len(deal) == n {
+			deals = append(deals, append([]Cards(nil), deal...))
+			return
+		}
+
+		for _, card := range deck {
+			if !card.CardsArePresent(used) {
+				deal = append(deal, card)
+				generate(used | card)
+				deal = deal[:len(deal)-1]
+			}
+		}
+	}
+	generate(NO_CARD)
+
+	return deals
+}
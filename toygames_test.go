@@ -0,0 +1,146 @@
+package poker_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/arturo-source/poker-engine"
+)
+
+func TestKuhnDeals(t *testing.T) {
+	want := 6
+	got := len(poker.KUHN.Deals(2))
+	if want != got {
+		t.Errorf("\nWant %d\nGot  %d", want, got)
+	}
+
+	want = 120
+	got = len(poker.LEDUC.Deals(2))
+	if want != got {
+		t.Errorf("\nWant %d\nGot  %d", want, got)
+	}
+}
+
+func TestKuhnHand(t *testing.T) {
+	p1 := poker.NewPlayer("P1")
+	p2 := poker.NewPlayer("P2")
+	p1.Coins, p2.Coins = 2, 2
+	g := poker.NewKuhnGame(p1, p2)
+
+	err := g.StartHand()
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if p1.Hand.Count() != 1 || p2.Hand.Count() != 1 || g.Pot != 2 {
+		t.Fatalf("Wrong start: %s %s %d", p1.Hand, p2.Hand, g.Pot)
+	}
+
+	mustApply(t, g, poker.BET, 1)
+	for _, a := range g.LegalActions() {
+		if a.Kind == poker.RAISE {
+			t.Errorf("Raising is not allowed in Kuhn poker")
+		}
+	}
+	mustApply(t, g, poker.CALL, 0)
+
+	if !g.HandIsOver() || g.Board.State != poker.SHOWDOWN || len(g.Board.TableCards) != 0 {
+		t.Fatalf("Hand should be over in the showdown without table cards")
+	}
+
+	winner, loser := p1, p2
+	if p2.Hand > p1.Hand {
+		winner, loser = p2, p1
+	}
+	if winner.Coins != 4 || loser.Coins != 0 {
+		t.Errorf("Wrong coins: winner %d, loser %d", winner.Coins, loser.Coins)
+	}
+}
+
+func TestLeducHand(t *testing.T) {
+	p1 := poker.NewPlayer("P1")
+	p2 := poker.NewPlayer("P2")
+	p1.Coins, p2.Coins = 20, 20
+	g := poker.NewLeducGame(p1, p2)
+	g.StartHand()
+
+	mustApply(t, g, poker.BET, 2)
+	mustApply(t, g, poker.RAISE, 4)
+	for _, a := range g.LegalActions() {
+		if a.Kind == poker.RAISE {
+			t.Errorf("Only a bet and a raise are allowed per round")
+		}
+	}
+	mustApply(t, g, poker.CALL, 0)
+
+	if g.Board.State != poker.FLOP || len(g.Board.TableCards) != 1 || g.Pot != 10 {
+		t.Fatalf("Wrong second round: %v %v %d", g.Board.State, g.Board.TableCards, g.Pot)
+	}
+
+	legalActions := g.LegalActions()
+	want := uint(4)
+	got := legalActions[len(legalActions)-1].Amount
+	if want != got {
+		t.Errorf("\nWant %d\nGot  %d", want, got)
+	}
+
+	for !g.HandIsOver() {
+		mustApply(t, g, poker.CHECK, 0)
+	}
+	if p1.Coins+p2.Coins != 40 {
+		t.Errorf("Coins are not conserved: %d", p1.Coins+p2.Coins)
+	}
+}
+
+func TestLeducHandValue(t *testing.T) {
+	c := poker.NewCard
+
+	pair := poker.LEDUC.HandValue(c("Js"), c("Jh"))
+	highCard := poker.LEDUC.HandValue(c("Ks"), c("Jh"))
+	if pair <= highCard {
+		t.Errorf("Pair of jacks (%d) should beat king (%d)", pair, highCard)
+	}
+}
+
+func TestCFRSolvesKuhn(t *testing.T) {
+	g := poker.NewKuhnGame(poker.NewPlayer("P1"), poker.NewPlayer("P2"))
+	for _, p := range g.Players {
+		p.Coins = 2
+	}
+
+	s, err := poker.NewCFRSolver(g, poker.KUHN.Deals(2), true)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	s.Iterate(2000)
+
+	exploitability, _ := s.Exploitability()
+	if exploitability > 0.001 {
+		t.Errorf("Exploitability is too high: %f", exploitability)
+	}
+
+	// The value of Kuhn poker for the first player is -1/18
+	want := -1.0 / 18
+	got := s.BestResponseValue(0)
+	if math.Abs(want-got) > 0.005 {
+		t.Errorf("\nWant %f\nGot  %f", want, got)
+	}
+}
+
+func TestCFRSolvesLeduc(t *testing.T) {
+	g := poker.NewLeducGame(poker.NewPlayer("P1"), poker.NewPlayer("P2"))
+	for _, p := range g.Players {
+		p.Coins = 20
+	}
+
+	s, err := poker.NewCFRSolver(g, poker.LEDUC.Deals(2), true)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	before, _ := s.Exploitability()
+	s.Iterate(200)
+	after, _ := s.Exploitability()
+	if after >= before || after > 0.05 {
+		t.Errorf("Exploitability didn't converge: before %f, after %f", before, after)
+	}
+}
@@ -6,6 +6,11 @@ var (
 	errNoCardsInDeck  = errors.New("no more cards in deck")
 	errNoCardsToFlip  = errors.New("no more cards to flip")
 	errMaxCardsInHand = errors.New("max cards added to hand")
+
+	errNotEnoughPlayers = errors.New("not enough players with coins to play a hand")
+	errNotPlayerTurn    = errors.New("it is not the turn of that player")
+	errIllegalAction    = errors.New("action is not legal")
+	errHandIsOver       = errors.New("the hand is over")
 )
 
 const (
@@ -150,3 +155,22 @@ func clamp(v, min, max int) int {
 
 	return v
 }
+
+func minUint(a, b uint) uint {
+	if a < b {
+		return a
+	}
+
+	return b
+}
+
+func clampUint(v, min, max uint) uint {
+	if v > max {
+		return max
+	}
+	if v < min {
+		return min
+	}
+
+	return v
+}
@@ -0,0 +1,59 @@
+package poker
+
+import "math/bits"
+
+// Variant describes the cards of a poker game played with Game: the cards in the deck,
+// how many cards are dealt to each player, how many table cards are flipped after each betting round
+// (there is one betting round more than elements in TableCards), and how hands are ranked.
+//
+// HandValue returns a number which is higher for better hands, and equal for ties.
+// If it is nil, hands are ranked as in hold'em (see BestHand and GetWinners).
+type Variant struct {
+	Name       string
+	Deck       Cards
+	HandCards  int
+	TableCards []int
+	BurnCards  bool
+	HandValue  func(hand, tableCards Cards) int
+}
+
+// HOLDEM is Texas hold'em, the variant played by default.
+var HOLDEM = &Variant{
+	Name:       "Texas hold'em",
+	Deck:       ALL_CARDS,
+	HandCards:  MAX_CARDS_PER_HAND,
+	TableCards: []int{3, 1, 1},
+	BurnCards:  true,
+}
+
+// winners returns the players with the best hand (more than one if there is a tie).
+func (v *Variant) winners(tableCards Cards, players []*Player) []*Player {
+	winners := make([]*Player, 0, len(players))
+
+	if v.HandValue == nil {
+		for _, hv := range GetWinners(tableCards, players) {
+			winners = append(winners, hv.Player)
+		}
+		return winners
+	}
+
+	best := 0
+	for _, p := range players {
+		value := v.HandValue(p.Hand, tableCards)
+		switch {
+		case len(winners) == 0 || value > best:
+			winners = append(winners[:0], p)
+			best = value
+		case value == best:
+			winners = append(winners, p)
+		}
+	}
+
+	return winners
+}
+
+// cardRank returns the rank of the card, from 0 (TWOS) to 12 (ACES).
+// If there are more cards, it returns the rank of the lowest bit.
+func cardRank(card Cards) int {
+	return bits.TrailingZeros64(uint64(card)) % 13
+}
@@ -0,0 +1,112 @@
+package poker
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SPECTATOR is the Seat of a GameView that doesn't belong to any player.
+const SPECTATOR = -1
+
+// SeatView is the information everybody at the table can see from a player.
+// Hand is NO_CARD while the cards are hidden to the viewer.
+type SeatView struct {
+	Name      string
+	Hand      Cards
+	Coins     uint
+	BetCoins  uint
+	HasFolded bool
+}
+
+// GameView is an observation of the game from the Seat of one player, or from a spectator.
+// It only contains the information the viewer is allowed to know,
+// and it is a copy, so modifying it never changes the Game.
+type GameView struct {
+	Seat       int
+	Hand       Cards
+	TableCards []Cards
+	State      BoardState
+	Players    []SeatView
+	History    []Action
+	Pot        uint
+}
+
+// ViewFor returns the game as the player p sees it: its own cards, and the public information of the others.
+// Opponents' cards are hidden until they show them (see Player.HasShown).
+// If p is not playing the game, it returns the SpectatorView.
+func (g *Game) ViewFor(p *Player) GameView {
+	seat := g.Seat(p)
+	if seat == SPECTATOR {
+		return g.SpectatorView()
+	}
+
+	view := g.view(seat)
+	view.Hand = p.Hand
+	view.Players[seat].Hand = p.Hand
+
+	return view
+}
+
+// SpectatorView returns the game as someone who is not playing sees it, only with the public information.
+func (g *Game) SpectatorView() GameView {
+	return g.view(SPECTATOR)
+}
+
+// Seat returns the index of p in Players, or SPECTATOR if p is not playing the game.
+func (g *Game) Seat(p *Player) int {
+	for i, player := range g.Players {
+		if player == p {
+			return i
+		}
+	}
+
+	return SPECTATOR
+}
+
+// view copies the public information of the game.
+func (g *Game) view(seat int) GameView {
+	view := GameView{
+		Seat:       seat,
+		Hand:       NO_CARD,
+		TableCards: append([]Cards(nil), g.Board.TableCards...),
+		State:      g.Board.State,
+		Players:    make([]SeatView, len(g.Players)),
+		History:    append([]Action(nil), g.History...),
+		Pot:        g.Pot,
+	}
+
+	for i, p := range g.Players {
+		view.Players[i] = SeatView{
+			Name:      p.Name,
+			Hand:      NO_CARD,
+			Coins:     p.Coins,
+			BetCoins:  p.BetCoins,
+			HasFolded: p.HasFolded,
+		}
+
+		if p.HasShown {
+			view.Players[i].Hand = p.Hand
+		}
+	}
+
+	return view
+}
+
+// InfoSet returns a string which identifies everything the viewer knows: its seat and cards,
+// the table cards, and the actions done by everybody.
+// Two views with the same InfoSet can't be distinguished by the viewer.
+func (v GameView) InfoSet() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%d|%s|%s|", v.Seat, v.Hand, JoinCards(v.TableCards...))
+
+	state := PREFLOP
+	for _, a := range v.History {
+		if a.State != state {
+			sb.WriteByte('/')
+			state = a.State
+		}
+		fmt.Fprintf(&sb, "%d%s%d,", a.Seat, a.Kind.String()[:2], a.Amount)
+	}
+
+	return sb.String()
+}
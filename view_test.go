@@ -0,0 +1,94 @@
+package poker_test
+
+import (
+	"testing"
+
+	"github.com/arturo-source/poker-engine"
+)
+
+func newViewGame() (*poker.Game, *poker.Player, *poker.Player) {
+	g := poker.NewGame()
+	p1 := poker.NewPlayer("P1")
+	p2 := poker.NewPlayer("P2")
+	g.Players = []*poker.Player{p1, p2}
+
+	c := poker.NewCard
+	p1.Hand = c("Ah") | c("Kh")
+	p2.Hand = c("5d") | c("5c")
+	p1.Coins, p1.BetCoins = 90, 10
+	p2.Coins, p2.BetCoins = 80, 20
+	g.Pot = 30
+	g.History = []poker.Action{{Seat: 0, Kind: poker.BET, Amount: 10}, {Seat: 1, Kind: poker.RAISE, Amount: 20}}
+
+	return g, p1, p2
+}
+
+func TestViewForHidesOpponentCards(t *testing.T) {
+	g, p1, _ := newViewGame()
+
+	view := g.ViewFor(p1)
+	if view.Seat != 0 {
+		t.Errorf("\nWant %d\nGot  %d", 0, view.Seat)
+	}
+	if view.Hand != p1.Hand {
+		t.Errorf("\nWant %s\nGot  %s", p1.Hand, view.Hand)
+	}
+	if view.Players[1].Hand != poker.NO_CARD {
+		t.Errorf("\nWant %s\nGot  %s", poker.NO_CARD, view.Players[1].Hand)
+	}
+	if view.Players[1].Coins != 80 || view.Players[1].BetCoins != 20 {
+		t.Errorf("Wrong opponent coins: %+v", view.Players[1])
+	}
+	if view.Pot != 30 || len(view.History) != 2 {
+		t.Errorf("Wrong pot or history: %d %v", view.Pot, view.History)
+	}
+}
+
+func TestViewForShowsCardsInShowdown(t *testing.T) {
+	g, p1, p2 := newViewGame()
+	g.Board.State = poker.SHOWDOWN
+	p2.HasShown = true
+
+	view := g.ViewFor(p1)
+	if view.Players[1].Hand != p2.Hand {
+		t.Errorf("\nWant %s\nGot  %s", p2.Hand, view.Players[1].Hand)
+	}
+
+	p2.HasShown = false
+	view = g.ViewFor(p1)
+	if view.Players[1].Hand != poker.NO_CARD {
+		t.Errorf("\nWant %s\nGot  %s", poker.NO_CARD, view.Players[1].Hand)
+	}
+}
+
+func TestSpectatorView(t *testing.T) {
+	g, _, _ := newViewGame()
+
+	view := g.ViewFor(poker.NewPlayer("Not playing"))
+	if view.Seat != poker.SPECTATOR {
+		t.Errorf("\nWant %d\nGot  %d", poker.SPECTATOR, view.Seat)
+	}
+	if view.Hand != poker.NO_CARD {
+		t.Errorf("\nWant %s\nGot  %s", poker.NO_CARD, view.Hand)
+	}
+	for i, p := range view.Players {
+		if p.Hand != poker.NO_CARD {
+			t.Errorf("Seat %d cards are visible to spectator: %s", i, p.Hand)
+		}
+	}
+}
+
+func TestViewIsACopy(t *testing.T) {
+	g, p1, _ := newViewGame()
+	c := poker.NewCard
+	g.Board.TableCards = []poker.Cards{c("2s"), c("3s"), c("4s")}
+
+	view := g.ViewFor(p1)
+	view.TableCards[0] = c("As")
+	view.History[0].Amount = 1000
+	view.Players[0].Coins = 0
+
+	if g.Board.TableCards[0] != c("2s") || g.History[0].Amount != 10 || p1.Coins != 90 {
+		t.Errorf("Modifying the view changed the game")
+	}
+}